@@ -0,0 +1,278 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// AsyncDroppedEvent is the COUNT event used to report events that an
+// asynchronous backend queue had to drop. It carries a "backend" prop naming
+// the wrapped backend type.
+const AsyncDroppedEvent = "emitter.async.dropped"
+
+// OverflowPolicy decides what an asynchronous backend queue does when it is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes the emitting goroutine wait for room in the queue.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest discards the event being emitted.
+	OverflowDropNewest
+	// OverflowDropOldest discards the oldest queued event to make room.
+	OverflowDropOldest
+)
+
+func (o OverflowPolicy) String() string {
+	switch o {
+	case OverflowBlock:
+		return "BLOCK"
+	case OverflowDropNewest:
+		return "DROP_NEWEST"
+	case OverflowDropOldest:
+		return "DROP_OLDEST"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// AsyncOptions configures asynchronous dispatch. See Emitter.WithAsync.
+type AsyncOptions struct {
+	// QueueSize is the number of events buffered per backend. Defaults to 1024.
+	QueueSize int
+	// Overflow is applied when a backend's queue is full. Defaults to OverflowBlock.
+	Overflow OverflowPolicy
+}
+
+const defaultAsyncQueueSize = 1024
+
+// asyncFlushPollInterval is how often Flush checks whether the queues drained.
+const asyncFlushPollInterval = time.Millisecond
+
+// asyncJob is a single queued emission.
+type asyncJob struct {
+	ctx   context.Context
+	event t.Event
+}
+
+// asyncBackend wraps an EventBackend with a bounded queue drained by a
+// single worker goroutine. Emissions return as soon as the event is queued.
+type asyncBackend struct {
+	backend  t.EventBackend
+	jobs     chan asyncJob
+	overflow OverflowPolicy
+
+	// pending counts events that were accepted but not yet processed or dropped.
+	pending atomic.Int64
+	// stats is shared with the backend's entry; drops are counted there.
+	stats *backendStats
+	// reported is the part of stats.dropped that has already been emitted as AsyncDroppedEvent.
+	reported atomic.Uint64
+
+	// mu is held for reading while an event is queued and for writing while
+	// closed is set, so that close cannot slip in between the closed check and
+	// the send and leave an event in a queue nobody drains.
+	mu        sync.RWMutex
+	closed    atomic.Bool
+	closeOnce sync.Once
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+func newAsyncBackend(backend t.EventBackend, opts AsyncOptions, stats *backendStats) *asyncBackend {
+	size := opts.QueueSize
+	if size <= 0 {
+		size = defaultAsyncQueueSize
+	}
+	a := &asyncBackend{
+		backend:  backend,
+		jobs:     make(chan asyncJob, size),
+		overflow: opts.Overflow,
+		stats:    stats,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+func (a *asyncBackend) run() {
+	defer close(a.stopped)
+	for {
+		select {
+		case job := <-a.jobs:
+			a.backend.Emit(job.ctx, &job.event)
+			a.pending.Add(-1)
+		case <-a.done:
+			return
+		}
+	}
+}
+
+func (a *asyncBackend) drop() {
+	a.pending.Add(-1)
+	a.stats.dropped.Add(1)
+}
+
+func (a *asyncBackend) enqueue(job asyncJob) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed.Load() {
+		a.stats.dropped.Add(1)
+		return
+	}
+
+	// The caller may reuse its props map and attrs and cancel its context once
+	// we return.
+	job.event.Props = maps.Clone(job.event.Props)
+	job.event.Attrs = slices.Clone(job.event.Attrs)
+	job.ctx = context.WithoutCancel(job.ctx)
+	a.pending.Add(1)
+
+	switch a.overflow {
+	case OverflowDropNewest:
+		select {
+		case a.jobs <- job:
+		default:
+			a.drop()
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case a.jobs <- job:
+				return
+			default:
+			}
+			select {
+			case <-a.jobs:
+				a.drop()
+			default:
+			}
+		}
+	default:
+		select {
+		case a.jobs <- job:
+		case <-a.done:
+			a.drop()
+		}
+	}
+}
+
+// flush waits until every accepted event has been handed to the backend or dropped.
+func (a *asyncBackend) flush(ctx context.Context) error {
+	if a.pending.Load() <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(asyncFlushPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if a.pending.Load() <= 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// close stops accepting events, drains the queue and stops the worker.
+func (a *asyncBackend) close(ctx context.Context) error {
+	a.mu.Lock()
+	a.closed.Store(true)
+	a.mu.Unlock()
+	err := a.flush(ctx)
+	a.closeOnce.Do(func() { close(a.done) })
+
+	select {
+	case <-a.stopped:
+	case <-ctx.Done():
+		if err == nil {
+			err = ctx.Err()
+		}
+	}
+	return err
+}
+
+// Emit satisfies the EventBackend interface by queueing a copy of the event
+func (a *asyncBackend) Emit(ctx context.Context, event *t.Event) {
+	a.enqueue(asyncJob{ctx: ctx, event: *event})
+}
+
+// WithAsync switches the emitter to asynchronous dispatch. Every backend,
+// including ones added later with WithBackend, gets its own bounded queue and
+// worker goroutine, so a slow backend no longer adds latency to the caller or
+// to the other backends. Sub-emitters created afterwards share the same queues.
+//
+// Call Flush to wait for queued events and Shutdown before exiting; events
+// emitted after Shutdown are dropped.
+func (e *Emitter) WithAsync(opts AsyncOptions) *Emitter {
+	e.backendsMu.Lock()
+	defer e.backendsMu.Unlock()
+
+	e.async = &opts
+	current := e.loadBackends()
+	wrapped := make([]*backendEntry, len(current))
+	for i, entry := range current {
+		if _, ok := entry.backend.(*asyncBackend); ok {
+			wrapped[i] = entry
+			continue
+		}
+		wrapped[i] = &backendEntry{
+			backend: newAsyncBackend(entry.backend, opts, entry.stats),
+			name:    entry.name,
+			route:   entry.route,
+			stats:   entry.stats,
+		}
+	}
+	e.backends.Store(&wrapped)
+	e.retain()
+	return e
+}
+
+func (e *Emitter) asyncBackends() []*asyncBackend {
+	var queues []*asyncBackend
+	for _, entry := range e.loadBackends() {
+		if a, ok := entry.backend.(*asyncBackend); ok {
+			queues = append(queues, a)
+		}
+	}
+	return queues
+}
+
+// DroppedEvents returns the total number of events dropped by the emitter's
+// asynchronous backend queues.
+func (e *Emitter) DroppedEvents() uint64 {
+	var total uint64
+	for _, a := range e.asyncBackends() {
+		total += a.stats.dropped.Load()
+	}
+	return total
+}
+
+// reportDrops emits AsyncDroppedEvent for drops that have not been reported
+// yet. Closed queues are skipped, since the report would only be dropped in
+// turn and counted as another drop.
+func (e *Emitter) reportDrops(ctx context.Context, queues []*asyncBackend) {
+	for _, a := range queues {
+		if a.closed.Load() {
+			continue
+		}
+		dropped := a.stats.dropped.Load()
+		reported := a.reported.Swap(dropped)
+		if dropped > reported {
+			// Internal events are not prefixed
+			ev := eventFromProps(AsyncDroppedEvent, map[string]interface{}{"backend": fmt.Sprintf("%T", unwrapBackend(a.backend))}, t.COUNT)
+			ev.Int = int64(dropped - reported)
+			e.emit(ctx, ev)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package emitter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// gatedBackend records int emissions and blocks while its gate is closed.
+type gatedBackend struct {
+	mu     sync.Mutex
+	gate   chan struct{}
+	events []string
+	values map[string]int64
+	props  map[string]map[string]interface{}
+}
+
+func newGatedBackend() *gatedBackend {
+	return &gatedBackend{gate: make(chan struct{}), values: make(map[string]int64), props: make(map[string]map[string]interface{})}
+}
+
+func (g *gatedBackend) open() { close(g.gate) }
+
+func (g *gatedBackend) record(event string, props map[string]interface{}, value int64) {
+	<-g.gate
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.events = append(g.events, event)
+	g.values[event] += value
+	g.props[event] = props
+}
+
+func (g *gatedBackend) Events() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.events...)
+}
+
+func (g *gatedBackend) Value(event string) int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.values[event]
+}
+
+func (g *gatedBackend) Props(event string) map[string]interface{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.props[event]
+}
+
+func (g *gatedBackend) EmitInt(ctx context.Context, event string, props map[string]interface{}, value int64, metricType MetricType) {
+	g.record(event, props, value)
+}
+
+func (g *gatedBackend) EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType MetricType) {
+	g.record(event, props, int64(value))
+}
+
+func (g *gatedBackend) EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType MetricType) {
+	g.record(event, props, int64(value))
+}
+
+var _ = Describe("Async dispatch", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("Should return before a blocked backend processes the event", func() {
+		backend := newGatedBackend()
+		emitter := NewEmitter(backend).WithAsync(AsyncOptions{QueueSize: 4})
+
+		emitter.Count(ctx, "event", nil, 1)
+		Expect(backend.Events()).To(BeEmpty())
+
+		backend.open()
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(backend.Events()).To(Equal([]string{"event"}))
+		Expect(emitter.Close(ctx)).To(Succeed())
+	})
+
+	It("Should not let a slow backend delay the others", func() {
+		slow := newGatedBackend()
+		fast := newGatedBackend()
+		fast.open()
+		emitter := NewEmitter(slow, fast).WithAsync(AsyncOptions{QueueSize: 4})
+
+		emitter.Count(ctx, "event", nil, 1)
+		Eventually(fast.Events).Should(Equal([]string{"event"}))
+		Expect(slow.Events()).To(BeEmpty())
+
+		slow.open()
+		Expect(emitter.Close(ctx)).To(Succeed())
+	})
+
+	It("Should not retain the caller's props map", func() {
+		backend := newGatedBackend()
+		emitter := NewEmitter(backend).WithAsync(AsyncOptions{})
+
+		props := map[string]interface{}{"key": "before"}
+		emitter.Count(ctx, "event", props, 1)
+		props["key"] = "after"
+
+		backend.open()
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(backend.Props("event")).To(HaveKeyWithValue("key", "before"))
+	})
+
+	It("Should drop the newest events when the queue is full", func() {
+		backend := newGatedBackend()
+		emitter := NewEmitter(backend).WithAsync(AsyncOptions{QueueSize: 1, Overflow: OverflowDropNewest})
+
+		emitter.Count(ctx, "first", nil, 1)
+		// Wait for the worker to pick up the first event so the queue is empty
+		Eventually(func() int { return len(emitter.asyncBackends()[0].jobs) }).Should(Equal(0))
+		emitter.Count(ctx, "second", nil, 1)
+		emitter.Count(ctx, "third", nil, 1)
+		Expect(emitter.DroppedEvents()).To(Equal(uint64(1)))
+
+		backend.open()
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(backend.Events()).To(ContainElements("first", "second"))
+		Expect(backend.Events()).NotTo(ContainElement("third"))
+	})
+
+	It("Should drop the oldest events when the queue is full", func() {
+		backend := newGatedBackend()
+		emitter := NewEmitter(backend).WithAsync(AsyncOptions{QueueSize: 1, Overflow: OverflowDropOldest})
+
+		emitter.Count(ctx, "first", nil, 1)
+		Eventually(func() int { return len(emitter.asyncBackends()[0].jobs) }).Should(Equal(0))
+		emitter.Count(ctx, "second", nil, 1)
+		emitter.Count(ctx, "third", nil, 1)
+		Expect(emitter.DroppedEvents()).To(Equal(uint64(1)))
+
+		backend.open()
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(backend.Events()).To(ContainElements("first", "third"))
+		Expect(backend.Events()).NotTo(ContainElement("second"))
+	})
+
+	It("Should block when the queue is full and the policy is OverflowBlock", func() {
+		backend := newGatedBackend()
+		emitter := NewEmitter(backend).WithAsync(AsyncOptions{QueueSize: 1, Overflow: OverflowBlock})
+
+		emitter.Count(ctx, "first", nil, 1)
+		Eventually(func() int { return len(emitter.asyncBackends()[0].jobs) }).Should(Equal(0))
+		emitter.Count(ctx, "second", nil, 1)
+
+		returned := make(chan struct{})
+		go func() {
+			emitter.Count(ctx, "third", nil, 1)
+			close(returned)
+		}()
+		Consistently(returned, 20*time.Millisecond).ShouldNot(BeClosed())
+
+		backend.open()
+		Eventually(returned).Should(BeClosed())
+		Expect(emitter.Close(ctx)).To(Succeed())
+		Expect(backend.Events()).To(Equal([]string{"first", "second", "third"}))
+		Expect(emitter.DroppedEvents()).To(BeZero())
+	})
+
+	It("Should report drops through the emitter on Flush", func() {
+		backend := newGatedBackend()
+		emitter := NewEmitter(backend).WithAsync(AsyncOptions{QueueSize: 1, Overflow: OverflowDropNewest})
+
+		emitter.Count(ctx, "first", nil, 1)
+		Eventually(func() int { return len(emitter.asyncBackends()[0].jobs) }).Should(Equal(0))
+		emitter.Count(ctx, "second", nil, 1)
+		emitter.Count(ctx, "third", nil, 1)
+		emitter.Count(ctx, "fourth", nil, 1)
+
+		backend.open()
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(backend.Value(AsyncDroppedEvent)).To(Equal(int64(2)))
+
+		// Drops are only reported once
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(backend.Value(AsyncDroppedEvent)).To(Equal(int64(2)))
+	})
+
+	It("Should time out Flush when the context expires", func() {
+		backend := newGatedBackend()
+		emitter := NewEmitter(backend).WithAsync(AsyncOptions{})
+		emitter.Count(ctx, "event", nil, 1)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		Expect(emitter.Flush(timeoutCtx)).To(MatchError(context.DeadlineExceeded))
+
+		backend.open()
+		Expect(emitter.Close(ctx)).To(Succeed())
+	})
+
+	It("Should drop events emitted after Close", func() {
+		backend := newGatedBackend()
+		backend.open()
+		emitter := NewEmitter(backend).WithAsync(AsyncOptions{})
+
+		emitter.Count(ctx, "before", nil, 1)
+		Expect(emitter.Close(ctx)).To(Succeed())
+		emitter.Count(ctx, "after", nil, 1)
+
+		Expect(backend.Events()).To(Equal([]string{"before"}))
+		Expect(emitter.DroppedEvents()).To(Equal(uint64(1)))
+
+		// Drop reports have nowhere to go after Close and are not counted as drops
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(emitter.DroppedEvents()).To(Equal(uint64(1)))
+		Expect(emitter.Stats().Dropped).To(Equal(uint64(1)))
+	})
+
+	It("Should deliver or drop every event emitted while shutting down", func() {
+		for _, overflow := range []OverflowPolicy{OverflowBlock, OverflowDropNewest, OverflowDropOldest} {
+			backend := newGatedBackend()
+			backend.open()
+			emitter := NewEmitter(backend).WithAsync(AsyncOptions{QueueSize: 1, Overflow: overflow})
+
+			var wg sync.WaitGroup
+			for range 8 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 100 {
+						emitter.Count(ctx, "event", nil, 1)
+					}
+				}()
+			}
+			Expect(emitter.Shutdown(ctx)).To(Succeed())
+			wg.Wait()
+			Expect(uint64(backend.Value("event")) + emitter.DroppedEvents()).To(Equal(uint64(800)), overflow.String())
+
+			// Nothing is left pending in a stopped queue
+			timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+			Expect(emitter.Flush(timeoutCtx)).To(Succeed(), overflow.String())
+			cancel()
+		}
+	})
+
+	It("Should queue backends added after WithAsync and share queues with sub-emitters", func() {
+		first := newGatedBackend()
+		second := newGatedBackend()
+		emitter := NewEmitter(first).WithAsync(AsyncOptions{}).WithBackend(second)
+		sub := emitter.NewSubEmitter().(*Emitter)
+
+		Expect(emitter.asyncBackends()).To(HaveLen(2))
+		Expect(sub.asyncBackends()).To(Equal(emitter.asyncBackends()))
+
+		sub.Count(ctx, "sub.event", nil, 1)
+		Expect(second.Events()).To(BeEmpty())
+
+		first.open()
+		second.open()
+		Expect(emitter.Close(ctx)).To(Succeed())
+		Expect(first.Events()).To(Equal([]string{"sub.event"}))
+		Expect(second.Events()).To(Equal([]string{"sub.event"}))
+	})
+
+	It("Should be a no-op to Flush and Close a synchronous emitter", func() {
+		emitter := NewEmitter(newGatedBackend())
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(emitter.Close(ctx)).To(Succeed())
+	})
+})
@@ -0,0 +1,151 @@
+package emitter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// String returns an Attr for a string value.
+func String(key string, value string) t.Attr {
+	return t.Attr{Key: key, Kind: t.AttrString, Str: value}
+}
+
+// Int returns an Attr for an int value.
+func Int(key string, value int) t.Attr {
+	return t.Attr{Key: key, Kind: t.AttrInt64, Num: int64(value)}
+}
+
+// Int64 returns an Attr for an int64 value.
+func Int64(key string, value int64) t.Attr {
+	return t.Attr{Key: key, Kind: t.AttrInt64, Num: value}
+}
+
+// Float64 returns an Attr for a float64 value.
+func Float64(key string, value float64) t.Attr {
+	return t.Attr{Key: key, Kind: t.AttrFloat64, Float: value}
+}
+
+// Bool returns an Attr for a bool value.
+func Bool(key string, value bool) t.Attr {
+	a := t.Attr{Key: key, Kind: t.AttrBool}
+	if value {
+		a.Num = 1
+	}
+	return a
+}
+
+// Duration returns an Attr for a time.Duration value.
+func Duration(key string, value time.Duration) t.Attr {
+	return t.Attr{Key: key, Kind: t.AttrDuration, Num: int64(value)}
+}
+
+// Any returns an Attr for any other value. The value is boxed, so prefer the
+// typed constructors on hot paths.
+func Any(key string, value interface{}) t.Attr {
+	return t.Attr{Key: key, Kind: t.AttrAny, Any: value}
+}
+
+// findAttr returns the last attr with key, which is the one that wins.
+func findAttr(attrs []t.Attr, key string) (t.Attr, bool) {
+	for i := len(attrs) - 1; i >= 0; i-- {
+		if attrs[i].Key == key {
+			return attrs[i], true
+		}
+	}
+	return t.Attr{}, false
+}
+
+// Events emitted through the *Attrs methods are pooled so that the hot path
+// does not allocate. Backends must not retain events, see types.EventBackend.
+var eventPool = sync.Pool{
+	New: func() interface{} { return &t.Event{} },
+}
+
+func acquireEvent(attrs []t.Attr) *t.Event {
+	ev := eventPool.Get().(*t.Event)
+	ev.Attrs = append(ev.Attrs[:0], attrs...)
+	return ev
+}
+
+func releaseEvent(ev *t.Event) {
+	// Drop references to attr values so that pooled events don't keep them alive
+	clear(ev.Attrs)
+	*ev = t.Event{Attrs: ev.Attrs[:0]}
+	eventPool.Put(ev)
+}
+
+func (e *Emitter) emitAttrs(ctx context.Context, ev *t.Event) {
+	e.emit(ctx, ev)
+	releaseEvent(ev)
+}
+
+// CountAttrs is Count with typed attrs instead of a props map. With no context
+// props, callback or magic props configured it does not allocate.
+func (e *Emitter) CountAttrs(ctx context.Context, event string, value int64, attrs ...t.Attr) {
+	ev := acquireEvent(attrs)
+	ev.Name = e.eventName(event)
+	ev.MetricType = t.COUNT
+	ev.ValueKind = t.IntValue
+	ev.Int = value
+	e.emitAttrs(ctx, ev)
+}
+
+// GaugeAttrs is Gauge with typed attrs instead of a props map.
+func (e *Emitter) GaugeAttrs(ctx context.Context, event string, value float64, attrs ...t.Attr) {
+	ev := acquireEvent(attrs)
+	ev.Name = e.eventName(event)
+	ev.MetricType = t.GAUGE
+	ev.ValueKind = t.FloatValue
+	ev.Float = value
+	e.emitAttrs(ctx, ev)
+}
+
+func (e *Emitter) logAttrs(ctx context.Context, event string, level t.Level, msg string, attrs []t.Attr) {
+	name := e.eventName(event)
+	if !e.enabled(name, level) {
+		return
+	}
+	ev := acquireEvent(attrs)
+	ev.Kind = t.LogEvent
+	ev.Name = name
+	ev.MetricType = t.COUNT
+	ev.ValueKind = t.IntValue
+	ev.Int = 1
+	ev.Level = level
+	ev.Message = msg
+	e.emitAttrs(ctx, ev)
+}
+
+// InfoAttrs is InfoContext with typed attrs instead of a props map.
+func (e *Emitter) InfoAttrs(ctx context.Context, event string, msg string, attrs ...t.Attr) {
+	e.logAttrs(ctx, event, t.LevelInfo, msg, attrs)
+}
+
+// WarnAttrs is WarnContext with typed attrs instead of a props map.
+func (e *Emitter) WarnAttrs(ctx context.Context, event string, msg string, attrs ...t.Attr) {
+	e.logAttrs(ctx, event, t.LevelWarn, msg, attrs)
+}
+
+// ErrorAttrs is ErrorContext with typed attrs instead of a props map.
+func (e *Emitter) ErrorAttrs(ctx context.Context, event string, msg string, attrs ...t.Attr) {
+	e.logAttrs(ctx, event, t.LevelError, msg, attrs)
+}
+
+// FatalAttrs is FatalContext with typed attrs instead of a props map.
+func (e *Emitter) FatalAttrs(ctx context.Context, event string, msg string, attrs ...t.Attr) {
+	e.logAttrs(ctx, event, t.LevelFatal, msg, attrs)
+	e.handleFatal(ctx, e.eventName(event), msg)
+}
+
+// DebugAttrs is DebugContext with typed attrs instead of a props map.
+func (e *Emitter) DebugAttrs(ctx context.Context, event string, msg string, attrs ...t.Attr) {
+	e.logAttrs(ctx, event, t.LevelDebug, msg, attrs)
+}
+
+// TraceAttrs is TraceContext with typed attrs instead of a props map.
+func (e *Emitter) TraceAttrs(ctx context.Context, event string, msg string, attrs ...t.Attr) {
+	e.logAttrs(ctx, event, t.LevelTrace, msg, attrs)
+}
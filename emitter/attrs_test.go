@@ -0,0 +1,137 @@
+package emitter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// discardBackend is an EventBackend that drops every event.
+type discardBackend struct{}
+
+func (discardBackend) Emit(ctx context.Context, event *Event) {}
+
+var _ = Describe("Attrs", func() {
+	var ctx context.Context
+	var recorder *eventRecorder
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &eventRecorder{}
+	})
+
+	It("Should build typed attrs", func() {
+		Expect(String("k", "v").Value()).To(Equal("v"))
+		Expect(Int("k", 3).Value()).To(Equal(int64(3)))
+		Expect(Int64("k", 3).String()).To(Equal("3"))
+		Expect(Float64("k", 1.5).String()).To(Equal("1.5"))
+		Expect(Bool("k", true).Value()).To(Equal(true))
+		Expect(Bool("k", false).String()).To(Equal("false"))
+		Expect(Duration("k", time.Second).Value()).To(Equal(time.Second))
+		Expect(Duration("k", time.Second).String()).To(Equal("1s"))
+		Expect(Any("k", []int{1}).String()).To(Equal("[1]"))
+	})
+
+	It("Should hand attrs to event backends without converting them", func() {
+		emitter := NewEmitter().WithEventBackend(recorder)
+
+		emitter.CountAttrs(ctx, "count", 2, String("route", "/"), Int("status", 200))
+		emitter.GaugeAttrs(ctx, "gauge", 0.5, Bool("cold", true))
+		emitter.WarnAttrs(ctx, "slow", "slow request", Duration("elapsed", time.Second))
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(3))
+		Expect(events[0].Int).To(Equal(int64(2)))
+		Expect(events[0].MetricType).To(Equal(COUNT))
+		Expect(events[0].Attrs).To(Equal([]Attr{String("route", "/"), Int("status", 200)}))
+		Expect(events[1].Float).To(Equal(0.5))
+		Expect(events[1].MetricType).To(Equal(GAUGE))
+		Expect(events[2].Kind).To(Equal(LogEvent))
+		Expect(events[2].Level).To(Equal(LevelWarn))
+		Expect(events[2].Message).To(Equal("slow request"))
+		Expect(events[2].Attrs).To(Equal([]Attr{Duration("elapsed", time.Second)}))
+	})
+
+	It("Should merge attrs into props for EmitterBackends", func() {
+		backend := newGatedBackend()
+		backend.open()
+		emitter := NewEmitter(backend)
+
+		ctx = ContextWithProps(ctx, map[string]interface{}{"route": "ctx", "region": "eu"})
+		emitter.CountAttrs(ctx, "count", 1, String("route", "/"), Int("status", 200))
+
+		Expect(backend.Props("count")).To(Equal(map[string]interface{}{"route": "/", "region": "eu", "status": int64(200)}))
+	})
+
+	It("Should apply log levels, sampling and suppression", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).
+			WithSampleRate("dropped", 0).
+			WithLogSuppression(SuppressionOptions{Window: time.Hour, KeyProps: []string{"host"}})
+		emitter.SetLevel(LevelInfo)
+
+		emitter.DebugAttrs(ctx, "debug", "below the minimum level")
+		emitter.CountAttrs(ctx, "dropped", 1)
+		for i := 0; i < 3; i++ {
+			emitter.ErrorAttrs(ctx, "db.down", "connection refused", String("host", "a"))
+		}
+		emitter.ErrorAttrs(ctx, "db.down", "connection refused", String("host", "b"))
+		Expect(emitter.Flush(ctx)).To(Succeed())
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(3))
+		Expect(events[2].Message).To(Equal("suppressed 2 occurrences"))
+		Expect(events[2].Attrs).To(Equal([]Attr{String("host", "a")}))
+	})
+
+	It("Should keep attrs of queued events", func() {
+		emitter := NewEmitter().WithAsync(AsyncOptions{}).WithEventBackend(recorder)
+
+		for i := 0; i < 10; i++ {
+			emitter.CountAttrs(ctx, "event", 1, Int("i", i))
+		}
+		Expect(emitter.Flush(ctx)).To(Succeed())
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(10))
+		for i, ev := range events {
+			Expect(ev.Attrs).To(Equal([]Attr{Int("i", i)}))
+		}
+	})
+
+	It("Should not allocate for a memoized count with attrs", func() {
+		emitter := NewEmitter().WithEventBackend(discardBackend{})
+		emitter.CountAttrs(ctx, "requests", 1)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			emitter.CountAttrs(ctx, "requests", 1, String("route", "/"), Int("status", 200), Bool("cached", true))
+		})
+		Expect(allocs).To(BeZero())
+	})
+})
+
+func BenchmarkCountAttrs(b *testing.B) {
+	ctx := context.Background()
+	emitter := NewEmitter().WithEventBackend(discardBackend{})
+	emitter.CountAttrs(ctx, "requests", 1)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		emitter.CountAttrs(ctx, "requests", 1, String("route", "/"), Int("status", 200), Bool("cached", true))
+	}
+}
+
+func BenchmarkCountProps(b *testing.B) {
+	ctx := context.Background()
+	emitter := NewEmitter().WithEventBackend(discardBackend{})
+	emitter.Count(ctx, "requests", nil, 1)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		emitter.Count(ctx, "requests", map[string]interface{}{"route": "/", "status": 200, "cached": true}, 1)
+	}
+}
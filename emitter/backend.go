@@ -0,0 +1,83 @@
+package emitter
+
+import (
+	"context"
+	"maps"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// legacyBackend adapts an EmitterBackend to the EventBackend interface. Log
+// events and sampled events are passed in the reserved props the
+// EmitterBackend convention uses.
+type legacyBackend struct {
+	backend t.EmitterBackend
+}
+
+// AdaptBackend wraps an EmitterBackend so that it can receive whole events.
+// The emitter does this automatically for backends that do not implement
+// types.EventBackend themselves.
+func AdaptBackend(backend t.EmitterBackend) t.EventBackend {
+	if b, ok := backend.(t.EventBackend); ok {
+		return b
+	}
+	return &legacyBackend{backend: backend}
+}
+
+// legacyProps returns the event's props with its attrs and the reserved props
+// added. The event's own map is never written to, and EmitterBackends always
+// get a non-nil map.
+func legacyProps(ev *t.Event) map[string]interface{} {
+	if ev.Kind != t.LogEvent && !ev.Sampled() && len(ev.Attrs) == 0 {
+		if ev.Props == nil {
+			return map[string]interface{}{}
+		}
+		return ev.Props
+	}
+
+	props := make(map[string]interface{}, len(ev.Props)+len(ev.Attrs)+4)
+	maps.Copy(props, ev.Props)
+	for _, a := range ev.Attrs {
+		props[a.Key] = a.Value()
+	}
+	if ev.Kind == t.LogEvent {
+		props["_message"] = ev.Message
+		props["_logLevel"] = ev.Level.String()
+	}
+	if ev.Sampled() {
+		props[SampleRateProp] = ev.SampleRate
+		props[SampledProp] = true
+	}
+	return props
+}
+
+func (l *legacyBackend) Emit(ctx context.Context, ev *t.Event) {
+	props := legacyProps(ev)
+	switch ev.ValueKind {
+	case t.IntValue:
+		l.backend.EmitInt(ctx, ev.Name, props, ev.Int, ev.MetricType)
+	case t.FloatValue:
+		l.backend.EmitFloat(ctx, ev.Name, props, ev.Float, ev.MetricType)
+	case t.DurationValue:
+		l.backend.EmitDuration(ctx, ev.Name, props, ev.Duration, ev.MetricType)
+	}
+}
+
+// unwrapBackend returns the backend the user added, looking through async
+// queues, redaction, per-backend middleware and the EmitterBackend adapter.
+func unwrapBackend(backend t.EventBackend) interface{} {
+	for {
+		switch b := backend.(type) {
+		case *asyncBackend:
+			backend = b.backend
+		case *redactingBackend:
+			backend = b.backend
+		case *middlewareBackend:
+			backend = b.backend
+		case *legacyBackend:
+			return b.backend
+		default:
+			return backend
+		}
+	}
+}
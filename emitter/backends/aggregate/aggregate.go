@@ -0,0 +1,355 @@
+// Package aggregate provides a backend wrapper that aggregates metrics in
+// memory and hands the aggregates to the wrapped backend on an interval, so
+// that hot counters cost one emission per interval instead of one per call:
+//
+//	backend := aggregate.NewAggregatingBackend(statsdBackend, aggregate.Options{Interval: 10 * time.Second})
+//	em := emitter.NewEmitter(backend)
+//	defer em.Shutdown(ctx)
+package aggregate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// Ticker delivers the ticks that trigger periodic flushes.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Clock creates the ticker that drives periodic flushes. Tests can supply a
+// clock whose ticks they send themselves.
+type Clock interface {
+	NewTicker(d time.Duration) Ticker
+}
+
+type realClock struct{}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+func (r realTicker) C() <-chan time.Time {
+	return r.Ticker.C
+}
+
+// Options configures an AggregatingBackend.
+type Options struct {
+	// Interval is how often aggregates are flushed. Defaults to 10 seconds.
+	Interval time.Duration
+	// Clock drives the flushes. Defaults to the system clock.
+	Clock Clock
+	// MaxSamples is how many HISTOGRAM or TIMER samples are kept per event and
+	// set of tags between flushes. Defaults to 1024.
+	MaxSamples int
+}
+
+const (
+	defaultInterval   = 10 * time.Second
+	defaultMaxSamples = 1024
+)
+
+// aggregate holds what was emitted for one event, metric type, value kind and
+// set of tags since the last flush. Counters and gauges keep a single value,
+// histograms and timers keep a uniform sample of at most MaxSamples values out
+// of the seen ones.
+type aggregate struct {
+	event      string
+	props      map[string]interface{}
+	metricType t.MetricType
+	kind       t.ValueKind
+	ints       []int64
+	floats     []float64
+	seen       int
+}
+
+// AggregatingBackend wraps an EmitterBackend. COUNT and METER values are
+// summed, GAUGE values keep the last value, and HISTOGRAM and TIMER samples
+// are collected; all of them are emitted to the wrapped backend on each flush,
+// with a background context. Logs and other metric types are passed through
+// immediately.
+//
+// Aggregates are keyed by event and tags, so events with different props are
+// flushed separately. Counters the emitter sampled are scaled up by their rate
+// and flushed as unsampled.
+//
+// Histogram and timer samples are flushed one emission each, so to bound
+// memory and flush cost only Options.MaxSamples of them are kept per key,
+// chosen uniformly by reservoir sampling. When samples were dropped, the kept
+// ones are flushed as sampled, with _rate set to the fraction kept. Dropped
+// reports how many samples were dropped.
+type AggregatingBackend struct {
+	backend    t.EmitterBackend
+	maxSamples int
+	dropped    atomic.Uint64
+
+	mu         sync.Mutex
+	aggregates map[string]*aggregate
+	closed     bool
+
+	// flushMu serializes flushes so that gauges reach the backend in order
+	flushMu sync.Mutex
+
+	ticker  Ticker
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewAggregatingBackend wraps backend and starts flushing every opts.Interval
+// until Close is called.
+func NewAggregatingBackend(backend t.EmitterBackend, opts Options) *AggregatingBackend {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultInterval
+	}
+	if opts.Clock == nil {
+		opts.Clock = realClock{}
+	}
+	if opts.MaxSamples <= 0 {
+		opts.MaxSamples = defaultMaxSamples
+	}
+	b := &AggregatingBackend{
+		backend:    backend,
+		maxSamples: opts.MaxSamples,
+		aggregates: make(map[string]*aggregate),
+		ticker:     opts.Clock.NewTicker(opts.Interval),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+func (b *AggregatingBackend) run() {
+	defer close(b.stopped)
+	for {
+		select {
+		case <-b.ticker.C():
+			b.flush(context.Background())
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// sampledRate returns the rate of an event that the emitter already sampled.
+func sampledRate(props map[string]interface{}) (float64, bool) {
+	if _, ok := props["_sampled"]; !ok {
+		return 1, false
+	}
+	rate, ok := props["_rate"].(float64)
+	if !ok || rate <= 0 || rate > 1 {
+		return 1, false
+	}
+	return rate, true
+}
+
+// aggregateKey identifies an aggregate by event, metric type, value kind and
+// the props sorted by key.
+func aggregateKey(event string, props map[string]interface{}, metricType t.MetricType, kind t.ValueKind) string {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\x00%d\x00%d", event, metricType, kind)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\x00%s=%v", k, props[k])
+	}
+	return b.String()
+}
+
+// add records a value, or reports false if the event is not aggregated.
+func (b *AggregatingBackend) add(event string, props map[string]interface{}, metricType t.MetricType, kind t.ValueKind, i int64, f float64) bool {
+	switch metricType {
+	case t.COUNT, t.METER, t.GAUGE, t.HISTOGRAM, t.TIMER:
+	default:
+		return false
+	}
+	if _, ok := props["_message"]; ok {
+		return false
+	}
+
+	if rate, ok := sampledRate(props); ok {
+		props = maps.Clone(props)
+		delete(props, "_rate")
+		delete(props, "_sampled")
+		if metricType == t.COUNT || metricType == t.METER {
+			if kind == t.FloatValue {
+				f /= rate
+			} else {
+				i = int64(math.Round(float64(i) / rate))
+			}
+		}
+	}
+	key := aggregateKey(event, props, metricType, kind)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return false
+	}
+
+	agg, ok := b.aggregates[key]
+	if !ok {
+		agg = &aggregate{event: event, props: maps.Clone(props), metricType: metricType, kind: kind}
+		b.aggregates[key] = agg
+	}
+	switch metricType {
+	case t.COUNT, t.METER:
+		if len(agg.ints) == 0 {
+			agg.ints, agg.floats = []int64{0}, []float64{0}
+		}
+		agg.ints[0] += i
+		agg.floats[0] += f
+	case t.GAUGE:
+		agg.ints, agg.floats = []int64{i}, []float64{f}
+	default:
+		agg.seen++
+		if len(agg.ints) < b.maxSamples {
+			agg.ints = append(agg.ints, i)
+			agg.floats = append(agg.floats, f)
+			break
+		}
+		// Replace a kept sample with probability maxSamples/seen
+		b.dropped.Add(1)
+		if n := rand.IntN(agg.seen); n < b.maxSamples {
+			agg.ints[n], agg.floats[n] = i, f
+		}
+	}
+	return true
+}
+
+// Dropped returns the number of HISTOGRAM and TIMER samples dropped so far
+// because their key already had Options.MaxSamples samples.
+func (b *AggregatingBackend) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// EmitInt satisfies the EmitterBackend interface
+func (b *AggregatingBackend) EmitInt(ctx context.Context, event string, props map[string]interface{}, value int64, metricType t.MetricType) {
+	if !b.add(event, props, metricType, t.IntValue, value, 0) {
+		b.backend.EmitInt(ctx, event, props, value, metricType)
+	}
+}
+
+// EmitFloat satisfies the EmitterBackend interface
+func (b *AggregatingBackend) EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType t.MetricType) {
+	if !b.add(event, props, metricType, t.FloatValue, 0, value) {
+		b.backend.EmitFloat(ctx, event, props, value, metricType)
+	}
+}
+
+// EmitDuration satisfies the EmitterBackend interface
+func (b *AggregatingBackend) EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType t.MetricType) {
+	if !b.add(event, props, metricType, t.DurationValue, int64(value), 0) {
+		b.backend.EmitDuration(ctx, event, props, value, metricType)
+	}
+}
+
+// flush emits the aggregates collected since the last flush, sorted by key.
+func (b *AggregatingBackend) flush(ctx context.Context) {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	aggregates := b.aggregates
+	b.aggregates = make(map[string]*aggregate)
+	b.mu.Unlock()
+
+	keys := make([]string, 0, len(aggregates))
+	for k := range aggregates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		agg := aggregates[k]
+		props := agg.props
+		if agg.seen > len(agg.ints) {
+			props = maps.Clone(props)
+			if props == nil {
+				props = make(map[string]interface{}, 2)
+			}
+			props["_rate"] = float64(len(agg.ints)) / float64(agg.seen)
+			props["_sampled"] = true
+		}
+		for n := range agg.ints {
+			switch agg.kind {
+			case t.IntValue:
+				b.backend.EmitInt(ctx, agg.event, props, agg.ints[n], agg.metricType)
+			case t.FloatValue:
+				b.backend.EmitFloat(ctx, agg.event, props, agg.floats[n], agg.metricType)
+			case t.DurationValue:
+				b.backend.EmitDuration(ctx, agg.event, props, time.Duration(agg.ints[n]), agg.metricType)
+			}
+		}
+	}
+}
+
+// Flush satisfies the t.Flusher interface. It emits the pending aggregates and
+// then flushes the wrapped backend if it is a t.Flusher.
+func (b *AggregatingBackend) Flush(ctx context.Context) error {
+	b.flush(ctx)
+	if f, ok := b.backend.(t.Flusher); ok {
+		return f.Flush(ctx)
+	}
+	return nil
+}
+
+// Close satisfies the t.Closer interface. It stops the periodic flushes,
+// emits the pending aggregates one last time and closes the wrapped backend
+// if it is a t.Closer. Values emitted afterwards are passed straight through.
+func (b *AggregatingBackend) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.done)
+	b.ticker.Stop()
+	<-b.stopped
+
+	if err := b.Flush(ctx); err != nil {
+		return err
+	}
+	if c, ok := b.backend.(t.Closer); ok {
+		return c.Close(ctx)
+	}
+	return nil
+}
+
+// SetErrorHandler satisfies the t.ErrorReporter interface by installing the
+// handler on the wrapped backend, if it reports errors.
+func (b *AggregatingBackend) SetErrorHandler(handler t.ErrorHandler) {
+	if r, ok := b.backend.(t.ErrorReporter); ok {
+		r.SetErrorHandler(handler)
+	}
+}
+
+// Describe satisfies the t.Describer interface by passing the event's info on
+// to the wrapped backend, if it uses it.
+func (b *AggregatingBackend) Describe(event string, metricType t.MetricType, info t.EventInfo) {
+	if d, ok := b.backend.(t.Describer); ok {
+		d.Describe(event, metricType, info)
+	}
+}
@@ -0,0 +1,207 @@
+package aggregate_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	emit "github.com/pseudofunctor-ai/go-emitter/emitter"
+	"github.com/pseudofunctor-ai/go-emitter/emitter/backends/aggregate"
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// manualClock hands out a ticker that only ticks when the test says so
+type manualClock struct {
+	ticks    chan time.Time
+	interval time.Duration
+	stopped  bool
+}
+
+func (c *manualClock) NewTicker(d time.Duration) aggregate.Ticker {
+	c.interval = d
+	return c
+}
+
+func (c *manualClock) C() <-chan time.Time { return c.ticks }
+func (c *manualClock) Stop()               { c.stopped = true }
+
+type emission struct {
+	Event string
+	Props map[string]interface{}
+	Value any
+	Type  t.MetricType
+}
+
+// recorder is a concurrency-safe EmitterBackend that also counts lifecycle calls
+type recorder struct {
+	mu        sync.Mutex
+	emissions []emission
+	flushes   int
+	closes    int
+}
+
+func (r *recorder) record(event string, props map[string]interface{}, value any, metricType t.MetricType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.emissions = append(r.emissions, emission{event, props, value, metricType})
+}
+
+func (r *recorder) EmitInt(ctx context.Context, event string, props map[string]interface{}, value int64, metricType t.MetricType) {
+	r.record(event, props, value, metricType)
+}
+
+func (r *recorder) EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType t.MetricType) {
+	r.record(event, props, value, metricType)
+}
+
+func (r *recorder) EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType t.MetricType) {
+	r.record(event, props, value, metricType)
+}
+
+func (r *recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.flushes++
+	return nil
+}
+
+func (r *recorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closes++
+	return nil
+}
+
+func (r *recorder) Emissions() []emission {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]emission(nil), r.emissions...)
+}
+
+var _ = Describe("AggregatingBackend", func() {
+	var (
+		ctx     context.Context
+		clock   *manualClock
+		rec     *recorder
+		backend *aggregate.AggregatingBackend
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		clock = &manualClock{ticks: make(chan time.Time)}
+		rec = &recorder{}
+		backend = aggregate.NewAggregatingBackend(rec, aggregate.Options{Interval: time.Minute, Clock: clock})
+	})
+
+	AfterEach(func() {
+		Expect(backend.Close(ctx)).To(Succeed())
+	})
+
+	It("Should aggregate until the clock ticks", func() {
+		Expect(clock.interval).To(Equal(time.Minute))
+
+		backend.EmitInt(ctx, "hits", map[string]interface{}{"route": "/a", "code": 200}, 1, t.COUNT)
+		backend.EmitInt(ctx, "hits", map[string]interface{}{"code": 200, "route": "/a"}, 2, t.COUNT)
+		backend.EmitInt(ctx, "hits", map[string]interface{}{"route": "/b", "code": 200}, 4, t.COUNT)
+		backend.EmitFloat(ctx, "queue", nil, 3, t.GAUGE)
+		backend.EmitFloat(ctx, "queue", nil, 5, t.GAUGE)
+		backend.EmitDuration(ctx, "latency", nil, time.Millisecond, t.TIMER)
+		backend.EmitDuration(ctx, "latency", nil, 2*time.Millisecond, t.TIMER)
+		Expect(rec.Emissions()).To(BeEmpty())
+
+		clock.ticks <- time.Now()
+		Eventually(rec.Emissions).Should(ConsistOf(
+			emission{"hits", map[string]interface{}{"route": "/a", "code": 200}, int64(3), t.COUNT},
+			emission{"hits", map[string]interface{}{"route": "/b", "code": 200}, int64(4), t.COUNT},
+			emission{"queue", nil, 5.0, t.GAUGE},
+			emission{"latency", nil, time.Millisecond, t.TIMER},
+			emission{"latency", nil, 2 * time.Millisecond, t.TIMER},
+		))
+
+		// Aggregates start over after a flush
+		backend.EmitInt(ctx, "hits", map[string]interface{}{"route": "/a", "code": 200}, 1, t.COUNT)
+		clock.ticks <- time.Now()
+		Eventually(rec.Emissions).Should(HaveLen(6))
+		Expect(rec.Emissions()[5].Value).To(Equal(int64(1)))
+	})
+
+	It("Should pass logs and unaggregated metric types through", func() {
+		backend.EmitInt(ctx, "started", map[string]interface{}{"_message": "hello", "_logLevel": "INFO"}, 1, t.COUNT)
+		backend.EmitInt(ctx, "users", nil, 42, t.SET)
+
+		Expect(rec.Emissions()).To(HaveLen(2))
+	})
+
+	It("Should scale sampled counters up", func() {
+		props := map[string]interface{}{"route": "/a", "_rate": 0.25, "_sampled": true}
+		backend.EmitInt(ctx, "hits", props, 1, t.COUNT)
+		backend.EmitInt(ctx, "hits", map[string]interface{}{"route": "/a"}, 1, t.COUNT)
+
+		Expect(backend.Flush(ctx)).To(Succeed())
+		Expect(rec.Emissions()).To(Equal([]emission{
+			{"hits", map[string]interface{}{"route": "/a"}, int64(5), t.COUNT},
+		}))
+		Expect(rec.flushes).To(Equal(1))
+	})
+
+	It("Should keep a bounded sample of histogram values", func() {
+		capped := aggregate.NewAggregatingBackend(rec, aggregate.Options{Clock: &manualClock{}, MaxSamples: 10})
+		defer capped.Close(ctx)
+
+		for n := range 100 {
+			capped.EmitDuration(ctx, "latency", map[string]interface{}{"route": "/a"}, time.Duration(n), t.TIMER)
+		}
+		capped.EmitFloat(ctx, "size", nil, 1, t.HISTOGRAM)
+		Expect(capped.Dropped()).To(Equal(uint64(90)))
+
+		Expect(capped.Flush(ctx)).To(Succeed())
+		emissions := rec.Emissions()
+		Expect(emissions).To(HaveLen(11))
+		for _, e := range emissions[:10] {
+			Expect(e.Event).To(Equal("latency"))
+			Expect(e.Props).To(Equal(map[string]interface{}{"route": "/a", "_rate": 0.1, "_sampled": true}))
+		}
+		Expect(emissions[10]).To(Equal(emission{"size", nil, 1.0, t.HISTOGRAM}))
+	})
+
+	It("Should be safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 100 {
+					backend.EmitInt(ctx, "hits", nil, 1, t.COUNT)
+				}
+			}()
+		}
+		clock.ticks <- time.Now()
+		wg.Wait()
+		Expect(backend.Flush(ctx)).To(Succeed())
+
+		var total int64
+		for _, e := range rec.Emissions() {
+			total += e.Value.(int64)
+		}
+		Expect(total).To(Equal(int64(800)))
+	})
+
+	It("Should flush once more when the emitter shuts down", func() {
+		em := emit.NewEmitter(backend)
+		em.Count(ctx, "hits", nil, 2)
+		em.Count(ctx, "hits", nil, 3)
+
+		Expect(em.Shutdown(ctx)).To(Succeed())
+		Expect(clock.stopped).To(BeTrue())
+		Expect(rec.Emissions()).To(HaveLen(1))
+		Expect(rec.Emissions()[0].Value).To(Equal(int64(5)))
+		Expect(rec.closes).To(Equal(1))
+
+		// Closed backends pass values straight through
+		backend.EmitInt(ctx, "hits", nil, 1, t.COUNT)
+		Expect(rec.Emissions()).To(HaveLen(2))
+	})
+})
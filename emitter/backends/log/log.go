@@ -3,8 +3,12 @@ package log
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"maps"
+	"reflect"
 	"sort"
 	"time"
 
@@ -19,7 +23,9 @@ type LoggerInterface interface {
 }
 
 type LogEmitter struct {
-	logger LoggerInterface
+	logger      LoggerInterface
+	output      io.Closer
+	sourceGroup bool
 }
 
 // NewLogEmitter creates a new slog emitter
@@ -29,9 +35,84 @@ func NewLogEmitter(logger LoggerInterface) *LogEmitter {
 	}
 }
 
-// mapToLogParams converts a map of properties to a slice of alternating keys
-// and values, which is required by the slog API
+// WithOutput registers the destination the logger writes to, typically an
+// *os.File, so that Flush syncs it and Close closes it.
+func (se *LogEmitter) WithOutput(output io.Closer) *LogEmitter {
+	se.output = output
+	return se
+}
+
+// WithSourceGroup logs the magic props (hostname, filename, lineNo, funcName
+// and package) in a "source" group, as slog does with AddSource, instead of
+// next to the other props. Within the group filename, lineNo and funcName
+// are named file, line and function, like slog.Source.
+func (se *LogEmitter) WithSourceGroup() *LogEmitter {
+	se.sourceGroup = true
+	return se
+}
+
+// sourceProps are the magic props and their keys in the source group, in
+// the order they are logged
+var sourceProps = [][2]string{
+	{"funcName", "function"},
+	{"filename", "file"},
+	{"lineNo", "line"},
+	{"package", "package"},
+	{"hostname", "hostname"},
+}
+
+// splitSource returns props without the magic props, and the magic props as
+// the attrs of the source group
+func splitSource(props map[string]interface{}) (map[string]interface{}, []slog.Attr) {
+	var source []slog.Attr
+	for _, p := range sourceProps {
+		if v, ok := props[p[0]]; ok {
+			source = append(source, slog.Attr{Key: p[1], Value: logValue(v)})
+		}
+	}
+	if len(source) == 0 {
+		return props, nil
+	}
+	props = maps.Clone(props)
+	for _, p := range sourceProps {
+		delete(props, p[0])
+	}
+	return props, source
+}
+
+// Flush satisfies the t.Flusher interface. It syncs the logger and the
+// registered output when they expose a Sync method, as *os.File and zap do.
+func (se *LogEmitter) Flush(ctx context.Context) error {
+	var errs []error
+	if s, ok := se.logger.(interface{ Sync() error }); ok {
+		errs = append(errs, s.Sync())
+	}
+	if s, ok := se.output.(interface{ Sync() error }); ok {
+		errs = append(errs, s.Sync())
+	}
+	return errors.Join(errs...)
+}
+
+// Close satisfies the t.Closer interface by closing the registered output
+func (se *LogEmitter) Close(ctx context.Context) error {
+	if se.output == nil {
+		return nil
+	}
+	return se.output.Close()
+}
+
+// mapToLogParams converts a map of properties to slog.Attrs sorted by key,
+// which the slog API accepts in place of alternating keys and values
 func mapToLogParams(props map[string]interface{}) []any {
+	attrs := propsToAttrs(props)
+	args := make([]any, len(attrs))
+	for i, a := range attrs {
+		args[i] = a
+	}
+	return args
+}
+
+func propsToAttrs(props map[string]interface{}) []slog.Attr {
 	keys := make([]string, 0, len(props))
 
 	for k := range props {
@@ -39,13 +120,54 @@ func mapToLogParams(props map[string]interface{}) []any {
 	}
 
 	sort.Strings(keys)
-	args := make([]any, 0, len(props)*2)
+	attrs := make([]slog.Attr, 0, len(props))
 
 	for _, k := range keys {
-		args = append(args, k, fmt.Sprintf("%v", props[k]))
+		attrs = append(attrs, slog.Attr{Key: k, Value: logValue(props[k])})
 	}
 
-	return args
+	return attrs
+}
+
+// logValue keeps the type of v for the slog handler. Maps with string keys
+// become groups; slog.LogValuers are left for the handler to resolve.
+func logValue(v interface{}) slog.Value {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return slog.GroupValue(propsToAttrs(v)...)
+	case slog.LogValuer, slog.Value:
+		return slog.AnyValue(v)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String {
+		props := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			props[iter.Key().String()] = iter.Value().Interface()
+		}
+		return slog.GroupValue(propsToAttrs(props)...)
+	}
+	return slog.AnyValue(v)
+}
+
+// attrToLogAttr converts a typed attr without boxing its value, except for
+// AttrAny values
+func attrToLogAttr(a t.Attr) slog.Attr {
+	switch a.Kind {
+	case t.AttrString:
+		return slog.String(a.Key, a.Str)
+	case t.AttrInt64:
+		return slog.Int64(a.Key, a.Num)
+	case t.AttrFloat64:
+		return slog.Float64(a.Key, a.Float)
+	case t.AttrBool:
+		return slog.Bool(a.Key, a.Num != 0)
+	case t.AttrDuration:
+		return slog.Duration(a.Key, time.Duration(a.Num))
+	default:
+		return slog.Attr{Key: a.Key, Value: logValue(a.Any)}
+	}
 }
 
 func isLog(props map[string]interface{}) (msg string, lvl string, isLog bool) {
@@ -79,23 +201,62 @@ func (se *LogEmitter) log(ctx context.Context, event string, props map[string]in
 	propsCopy := maps.Clone(props)
 	delete(propsCopy, "_message")
 	delete(propsCopy, "_logLevel")
+	// _rate is kept so readers know the log was sampled
+	delete(propsCopy, "_sampled")
 
+	se.write(ctx, level, message, propsCopy, nil)
+	return nil
+}
+
+// write logs the source group, if any, then the props sorted by key, followed
+// by the attrs in the order they were given
+func (se *LogEmitter) write(ctx context.Context, level string, message string, props map[string]interface{}, attrs []t.Attr) {
+	var args []any
+	if se.sourceGroup {
+		var source []slog.Attr
+		if props, source = splitSource(props); len(source) > 0 {
+			args = append(args, slog.Attr{Key: slog.SourceKey, Value: slog.GroupValue(source...)})
+		}
+	}
+	args = append(args, mapToLogParams(props)...)
+	for _, a := range attrs {
+		args = append(args, attrToLogAttr(a))
+	}
 	switch level {
 	case "INFO":
-		se.logger.InfoContext(ctx, message, mapToLogParams(propsCopy)...)
+		se.logger.InfoContext(ctx, message, args...)
 	case "WARN":
-		se.logger.WarnContext(ctx, message, mapToLogParams(propsCopy)...)
+		se.logger.WarnContext(ctx, message, args...)
 	case "ERROR":
-		se.logger.ErrorContext(ctx, message, mapToLogParams(propsCopy)...)
+		se.logger.ErrorContext(ctx, message, args...)
 	case "FATAL":
-		se.logger.ErrorContext(ctx, message, mapToLogParams(propsCopy)...)
+		se.logger.ErrorContext(ctx, message, args...)
 	case "DEBUG":
-		se.logger.DebugContext(ctx, message, mapToLogParams(propsCopy)...)
+		se.logger.DebugContext(ctx, message, args...)
 	case "TRACE":
-		se.logger.DebugContext(ctx, message, mapToLogParams(propsCopy)...)
+		se.logger.DebugContext(ctx, message, args...)
 	}
+}
 
-	return nil
+// Emit satisfies the t.EventBackend interface and logs log events; metric
+// events are ignored. Sampled logs carry their rate as _rate.
+func (se *LogEmitter) Emit(ctx context.Context, event *t.Event) {
+	if event.Kind != t.LogEvent {
+		return
+	}
+	props := event.Props
+	if event.Sampled() || len(event.Attrs) > 0 {
+		props = make(map[string]interface{}, len(event.Props)+1)
+		maps.Copy(props, event.Props)
+		// Attrs win over props with the same key
+		for _, a := range event.Attrs {
+			delete(props, a.Key)
+		}
+		if event.Sampled() {
+			props["_rate"] = event.SampleRate
+		}
+	}
+	se.write(ctx, event.Level.String(), event.Message, props, event.Attrs)
 }
 
 // EmitFloat satisfies the EmitterBackend interface and for this backend logs the event as a structured log
@@ -1,8 +1,11 @@
 package log
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"log/slog"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -13,6 +16,24 @@ import (
 	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
 )
 
+// attrMatcher matches a slog.Attr equal to slog.Any(key, value)
+type attrMatcher struct {
+	attr slog.Attr
+}
+
+func attr(key string, value any) gomock.Matcher {
+	return attrMatcher{slog.Any(key, value)}
+}
+
+func (m attrMatcher) Matches(x any) bool {
+	a, ok := x.(slog.Attr)
+	return ok && a.Equal(m.attr)
+}
+
+func (m attrMatcher) String() string {
+	return "is slog.Attr " + m.attr.String()
+}
+
 var _ = Describe("Interface", func() {
 	It("should work with slog, otherwise what are we even doing here", func() {
 		slog := slog.Default()
@@ -96,7 +117,7 @@ var _ = Describe("LogEmitter", func() {
 		logEmitter := NewLogEmitter(log)
 
 		ctx := context.Background()
-		log.EXPECT().InfoContext(ctx, "Hello World!", "Hello", "1", "foo", "bar")
+		log.EXPECT().InfoContext(ctx, "Hello World!", attr("Hello", 1), attr("foo", "bar"))
 		logEmitter.EmitInt(ctx, "test", map[string]interface{}{"_message": "Hello World!", "_logLevel": "INFO", "foo": "bar", "Hello": 1}, 1, t.COUNT)
 	})
 
@@ -111,3 +132,161 @@ var _ = Describe("LogEmitter", func() {
 		emitter.Info("test", nil, "Hello World!")
 	})
 })
+
+// syncCloser records Sync and Close calls on a log output
+type syncCloser struct {
+	synced bool
+	closed bool
+}
+
+func (s *syncCloser) Sync() error {
+	s.synced = true
+	return nil
+}
+
+func (s *syncCloser) Close() error {
+	s.closed = true
+	return nil
+}
+
+var _ = Describe("Events", func() {
+	It("should log log events and ignore metric events", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		log := mocks.NewMockLoggerInterface(ctrl)
+		logEmitter := NewLogEmitter(log)
+
+		ctx := context.Background()
+		log.EXPECT().WarnContext(ctx, "Hello World!", attr("host", "a"))
+		logEmitter.Emit(ctx, &t.Event{Kind: t.LogEvent, Name: "test", Level: t.LevelWarn, Message: "Hello World!", Props: map[string]interface{}{"host": "a"}})
+		logEmitter.Emit(ctx, &t.Event{Kind: t.MetricEvent, Name: "test", Props: map[string]interface{}{}})
+	})
+
+	It("should include the rate of sampled logs", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		log := mocks.NewMockLoggerInterface(ctrl)
+		logEmitter := NewLogEmitter(log)
+
+		ctx := context.Background()
+		log.EXPECT().DebugContext(ctx, "Hello World!", attr("_rate", 0.1))
+		logEmitter.Emit(ctx, &t.Event{Kind: t.LogEvent, Name: "test", Level: t.LevelDebug, Message: "Hello World!", SampleRate: 0.1})
+	})
+
+	It("should log attrs after props", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		log := mocks.NewMockLoggerInterface(ctrl)
+		logEmitter := NewLogEmitter(log)
+
+		ctx := context.Background()
+		log.EXPECT().ErrorContext(ctx, "Hello World!", attr("region", "eu"), attr("host", "b"), attr("elapsed", time.Second))
+		logEmitter.Emit(ctx, &t.Event{Kind: t.LogEvent, Name: "test", Level: t.LevelError, Message: "Hello World!", Props: map[string]interface{}{"host": "a", "region": "eu"}, Attrs: []t.Attr{
+			{Key: "host", Kind: t.AttrString, Str: "b"},
+			emit.Duration("elapsed", time.Second),
+		}})
+	})
+})
+
+// secret hides its value from logs
+type secret string
+
+func (secret) LogValue() slog.Value {
+	return slog.StringValue("REDACTED")
+}
+
+var _ = Describe("Structured values", func() {
+	var buf *bytes.Buffer
+	var logger *slog.Logger
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		logger = slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+	})
+
+	decode := func() map[string]interface{} {
+		var line map[string]interface{}
+		Expect(json.Unmarshal(buf.Bytes(), &line)).To(Succeed())
+		return line
+	}
+
+	It("should keep native types, resolve LogValuers and group nested maps", func() {
+		type user struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+		logEmitter := NewLogEmitter(logger)
+		logEmitter.Emit(context.Background(), &t.Event{Kind: t.LogEvent, Level: t.LevelInfo, Message: "hello", Props: map[string]interface{}{
+			"count":    42,
+			"ok":       true,
+			"ratio":    0.5,
+			"elapsed":  time.Millisecond,
+			"password": secret("hunter2"),
+			"user":     user{ID: 7, Name: "alice"},
+			"request":  map[string]interface{}{"route": "/users", "headers": map[string]string{"accept": "json"}},
+		}, Attrs: []t.Attr{emit.Int("attempt", 2)}})
+
+		Expect(decode()).To(Equal(map[string]interface{}{
+			"level":    "INFO",
+			"msg":      "hello",
+			"count":    42.0,
+			"ok":       true,
+			"ratio":    0.5,
+			"elapsed":  float64(time.Millisecond),
+			"password": "REDACTED",
+			"user":     map[string]interface{}{"id": 7.0, "name": "alice"},
+			"request":  map[string]interface{}{"route": "/users", "headers": map[string]interface{}{"accept": "json"}},
+			"attempt":  2.0,
+		}))
+	})
+
+	It("should put magic props in a source group when asked to", func() {
+		em := emit.NewEmitter(NewLogEmitter(logger).WithSourceGroup()).
+			WithAllMagicProps().
+			WithHostnameProvider(func() (string, error) { return "app-01", nil }).
+			WithCallsiteProvider(emit.StaticCallsiteProvider(map[string]t.CallSiteDetails{
+				"login": {Filename: "auth.go", LineNo: 12, FuncName: "Login", Package: "auth"},
+			}))
+		em.Info("login", map[string]interface{}{"user": "alice"}, "logged in")
+
+		Expect(decode()).To(Equal(map[string]interface{}{
+			"level": "INFO",
+			"msg":   "logged in",
+			"user":  "alice",
+			"source": map[string]interface{}{
+				"function": "Login",
+				"file":     "auth.go",
+				"line":     12.0,
+				"package":  "auth",
+				"hostname": "app-01",
+			},
+		}))
+	})
+})
+
+var _ = Describe("Lifecycle", func() {
+	It("should sync and close the registered output on shutdown", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		output := &syncCloser{}
+		logEmitter := NewLogEmitter(mocks.NewMockLoggerInterface(ctrl)).WithOutput(output)
+
+		Expect(emit.NewEmitter(logEmitter).Shutdown(context.Background())).To(Succeed())
+		Expect(output.synced).To(BeTrue())
+		Expect(output.closed).To(BeTrue())
+	})
+
+	It("should be a no-op without an output", func() {
+		logEmitter := NewLogEmitter(slog.Default())
+
+		Expect(logEmitter.Flush(context.Background())).To(Succeed())
+		Expect(logEmitter.Close(context.Background())).To(Succeed())
+	})
+})
@@ -0,0 +1,139 @@
+package otel
+
+import (
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// InstrumentConfig configures the instrument created for an event, see
+// WithInstruments. Fields that are set override the registration options of
+// the event.
+type InstrumentConfig struct {
+	Description string
+	// Unit is the instrument's unit. Durations are recorded in seconds unless
+	// it is "ms", "us" or "ns".
+	Unit string
+	// Buckets are explicit bucket boundaries for histograms and timers, in Unit
+	Buckets []float64
+	// Exponential asks for a base-2 exponential histogram instead. Aggregations
+	// are chosen by the SDK, so it only takes effect through Views.
+	Exponential *ExponentialHistogram
+}
+
+// ExponentialHistogram configures a base-2 exponential histogram aggregation.
+// Zero values use the SDK's defaults.
+type ExponentialHistogram struct {
+	// MaxSize is the maximum number of buckets, 160 by default
+	MaxSize int32
+	// MaxScale is the maximum resolution scale, from -10 to 20, 20 by default
+	MaxScale int32
+}
+
+// WithInstruments configures the instruments of the given events, by event
+// name. It only applies to instruments that have not been created yet, so it
+// should be called before the backend is used.
+func (b *OtelBackend) WithInstruments(config map[string]InstrumentConfig) *OtelBackend {
+	if b.instruments == nil {
+		b.instruments = make(map[string]InstrumentConfig, len(config))
+	}
+	for name, c := range config {
+		b.instruments[name] = c
+	}
+	return b
+}
+
+// Views returns the SDK views that apply the Exponential hints in config. Pass
+// them to the meter provider the backend's meter comes from:
+//
+//	provider := sdkmetric.NewMeterProvider(
+//		sdkmetric.WithReader(reader),
+//		sdkmetric.WithView(otel.Views(instruments)...),
+//	)
+//	backend := otel.NewOtelBackend(provider.Meter("app")).WithInstruments(instruments)
+func Views(config map[string]InstrumentConfig) []sdkmetric.View {
+	names := make([]string, 0, len(config))
+	for name, c := range config {
+		if c.Exponential != nil {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	views := make([]sdkmetric.View, 0, len(names))
+	for _, name := range names {
+		exp := config[name].Exponential
+		aggregation := sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: 160, MaxScale: 20}
+		if exp.MaxSize != 0 {
+			aggregation.MaxSize = exp.MaxSize
+		}
+		if exp.MaxScale != 0 {
+			aggregation.MaxScale = exp.MaxScale
+		}
+		views = append(views, sdkmetric.NewView(
+			sdkmetric.Instrument{Name: name, Kind: sdkmetric.InstrumentKindHistogram},
+			sdkmetric.Stream{Aggregation: aggregation},
+		))
+	}
+	return views
+}
+
+// instrumentConfig returns the configuration of the instrument for event: the
+// registration options described to the backend, overridden by the
+// configuration given to WithInstruments.
+func (b *OtelBackend) instrumentConfig(event string) InstrumentConfig {
+	var c InstrumentConfig
+	if v, ok := b.info.Load(event); ok {
+		info := v.(t.EventInfo)
+		c.Description = info.Description
+		c.Unit = info.Unit
+		c.Buckets = info.Buckets
+	}
+	if override, ok := b.instruments[event]; ok {
+		if override.Description != "" {
+			c.Description = override.Description
+		}
+		if override.Unit != "" {
+			c.Unit = override.Unit
+		}
+		if override.Buckets != nil {
+			c.Buckets = override.Buckets
+		}
+		c.Exponential = override.Exponential
+	}
+	return c
+}
+
+// instrumentInfo returns the description and unit to create the instrument
+// for event with
+func (b *OtelBackend) instrumentInfo(event string) (description, unit metric.InstrumentOption) {
+	c := b.instrumentConfig(event)
+	return metric.WithDescription(c.Description), metric.WithUnit(c.Unit)
+}
+
+// explicitBuckets returns the bucket boundaries to create a histogram with,
+// or nil to leave them to the SDK
+func (c InstrumentConfig) explicitBuckets() []float64 {
+	if len(c.Buckets) == 0 || c.Exponential != nil {
+		return nil
+	}
+	return c.Buckets
+}
+
+// durationScale returns the number of Unit in a duration's seconds
+func durationScale(unit string) float64 {
+	switch unit {
+	case "ms":
+		return float64(time.Second / time.Millisecond)
+	case "us":
+		return float64(time.Second / time.Microsecond)
+	case "ns":
+		return float64(time.Second / time.Nanosecond)
+	default:
+		return 1
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"math"
 	"sync"
 	"time"
 
@@ -13,9 +14,19 @@ import (
 	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
 )
 
+// ProviderLifecycle is implemented by SDK meter providers such as
+// sdkmetric.MeterProvider. It lets the backend flush and shut down the
+// provider that owns its meter.
+type ProviderLifecycle interface {
+	ForceFlush(ctx context.Context) error
+	Shutdown(ctx context.Context) error
+}
+
 // OtelBackend implements EmitterBackend for OpenTelemetry metrics
 type OtelBackend struct {
-	meter metric.Meter
+	meter    metric.Meter
+	provider ProviderLifecycle
+	onError  t.ErrorHandler
 
 	// Cache instruments to avoid recreating them
 	int64Counters     sync.Map // map[string]metric.Int64Counter
@@ -24,6 +35,14 @@ type OtelBackend struct {
 	float64Gauges     sync.Map // map[string]metric.Float64Gauge
 	int64Histograms   sync.Map // map[string]metric.Int64Histogram
 	float64Histograms sync.Map // map[string]metric.Float64Histogram
+
+	// info holds the registration options of registered events, see Describe
+	info sync.Map // map[string]t.EventInfo
+	// instruments is set by WithInstruments
+	instruments map[string]InstrumentConfig
+	// durationScales holds the factor from seconds to the unit of each float64
+	// histogram, for recording durations
+	durationScales sync.Map // map[string]float64
 }
 
 // NewOtelBackend creates a new OpenTelemetry backend
@@ -33,73 +52,196 @@ func NewOtelBackend(meter metric.Meter) *OtelBackend {
 	}
 }
 
+// SetErrorHandler satisfies the t.ErrorReporter interface. The handler receives
+// errors from creating instruments.
+func (b *OtelBackend) SetErrorHandler(handler t.ErrorHandler) {
+	b.onError = handler
+}
+
+func (b *OtelBackend) reportError(ctx context.Context, event string, err error) {
+	if b.onError != nil {
+		b.onError(ctx, event, err)
+	}
+}
+
+// Describe satisfies the t.Describer interface. The description, unit and
+// buckets of a registered event are used when its instrument is created, so
+// they only apply to instruments that have not been created yet.
+func (b *OtelBackend) Describe(event string, metricType t.MetricType, info t.EventInfo) {
+	b.info.Store(event, info)
+}
+
+// WithProvider attaches the meter provider that owns the backend's meter, so
+// that Flush and Close force a collection and shut the provider down.
+func (b *OtelBackend) WithProvider(provider ProviderLifecycle) *OtelBackend {
+	b.provider = provider
+	return b
+}
+
+// Flush satisfies the t.Flusher interface by forcing the provider to export
+func (b *OtelBackend) Flush(ctx context.Context) error {
+	if b.provider == nil {
+		return nil
+	}
+	return b.provider.ForceFlush(ctx)
+}
+
+// Close satisfies the t.Closer interface by shutting the provider down
+func (b *OtelBackend) Close(ctx context.Context) error {
+	if b.provider == nil {
+		return nil
+	}
+	return b.provider.Shutdown(ctx)
+}
+
+// sampledRate returns the rate of an event that the emitter already sampled,
+// so counters can be scaled back up to an estimate of the real count
+func sampledRate(props map[string]interface{}) (float64, bool) {
+	if _, ok := props["_sampled"]; !ok {
+		return 1, false
+	}
+	rate, ok := props["_rate"].(float64)
+	if !ok || rate <= 0 || rate > 1 {
+		return 1, false
+	}
+	return rate, true
+}
+
 // propsToAttributes converts a property map to OpenTelemetry attributes
-// It filters out special properties like _rate, _message, _logLevel
+// It filters out special properties like _rate, _sampled, _message, _logLevel
 func propsToAttributes(props map[string]interface{}) []attribute.KeyValue {
 	p := maps.Clone(props)
 
 	// Remove special properties that aren't meant to be attributes
 	delete(p, "_rate")
+	delete(p, "_sampled")
 	delete(p, "_message")
 	delete(p, "_logLevel")
 
 	attrs := make([]attribute.KeyValue, 0, len(p))
 	for k, v := range p {
-		switch val := v.(type) {
-		case string:
-			attrs = append(attrs, attribute.String(k, val))
-		case bool:
-			attrs = append(attrs, attribute.Bool(k, val))
-		// All int flavors -> int64
-		case int:
-			attrs = append(attrs, attribute.Int64(k, int64(val)))
-		case int8:
-			attrs = append(attrs, attribute.Int64(k, int64(val)))
-		case int16:
-			attrs = append(attrs, attribute.Int64(k, int64(val)))
-		case int32:
-			attrs = append(attrs, attribute.Int64(k, int64(val)))
-		case int64:
-			attrs = append(attrs, attribute.Int64(k, val))
-		case uint:
-			attrs = append(attrs, attribute.Int64(k, int64(val)))
-		case uint8:
-			attrs = append(attrs, attribute.Int64(k, int64(val)))
-		case uint16:
-			attrs = append(attrs, attribute.Int64(k, int64(val)))
-		case uint32:
-			attrs = append(attrs, attribute.Int64(k, int64(val)))
-		case uint64:
-			attrs = append(attrs, attribute.Int64(k, int64(val)))
-		// All float flavors -> float64
-		case float32:
-			attrs = append(attrs, attribute.Float64(k, float64(val)))
-		case float64:
-			attrs = append(attrs, attribute.Float64(k, val))
+		attrs = append(attrs, valueToAttribute(k, v))
+	}
+	return attrs
+}
+
+// valueToAttribute converts a single prop value, keeping its type where
+// OpenTelemetry has a matching attribute type
+func valueToAttribute(k string, v interface{}) attribute.KeyValue {
+	switch val := v.(type) {
+	case string:
+		return attribute.String(k, val)
+	case bool:
+		return attribute.Bool(k, val)
+	// All int flavors -> int64
+	case int:
+		return attribute.Int64(k, int64(val))
+	case int8:
+		return attribute.Int64(k, int64(val))
+	case int16:
+		return attribute.Int64(k, int64(val))
+	case int32:
+		return attribute.Int64(k, int64(val))
+	case int64:
+		return attribute.Int64(k, val)
+	case uint:
+		return attribute.Int64(k, int64(val))
+	case uint8:
+		return attribute.Int64(k, int64(val))
+	case uint16:
+		return attribute.Int64(k, int64(val))
+	case uint32:
+		return attribute.Int64(k, int64(val))
+	case uint64:
+		return attribute.Int64(k, int64(val))
+	// All float flavors -> float64
+	case float32:
+		return attribute.Float64(k, float64(val))
+	case float64:
+		return attribute.Float64(k, val)
+	default:
+		// Fallback to string representation
+		return attribute.String(k, fmt.Sprintf("%v", val))
+	}
+}
+
+// eventAttributes converts the event's props and attrs to OpenTelemetry
+// attributes. Attrs are converted by kind, without boxing their values, and
+// come last so that they win over props with the same key.
+func eventAttributes(event *t.Event) []attribute.KeyValue {
+	attrs := propsToAttributes(event.Props)
+	for _, a := range event.Attrs {
+		switch a.Kind {
+		case t.AttrString:
+			attrs = append(attrs, attribute.String(a.Key, a.Str))
+		case t.AttrInt64:
+			attrs = append(attrs, attribute.Int64(a.Key, a.Num))
+		case t.AttrFloat64:
+			attrs = append(attrs, attribute.Float64(a.Key, a.Float))
+		case t.AttrBool:
+			attrs = append(attrs, attribute.Bool(a.Key, a.Num != 0))
+		case t.AttrDuration:
+			attrs = append(attrs, attribute.String(a.Key, a.String()))
 		default:
-			// Fallback to string representation
-			attrs = append(attrs, attribute.String(k, fmt.Sprintf("%v", val)))
+			attrs = append(attrs, valueToAttribute(a.Key, a.Any))
 		}
 	}
 	return attrs
 }
 
+// Emit implements EventBackend.Emit. Log events are recorded as counters.
+func (b *OtelBackend) Emit(ctx context.Context, event *t.Event) {
+	opts := metric.WithAttributes(eventAttributes(event)...)
+	rate := 1.0
+	if event.Sampled() {
+		rate = event.SampleRate
+	}
+
+	switch event.ValueKind {
+	case t.IntValue:
+		b.emitInt(ctx, event.Name, opts, event.Int, event.MetricType, rate)
+	case t.FloatValue:
+		b.emitFloat(ctx, event.Name, opts, event.Float, event.MetricType, rate)
+	case t.DurationValue:
+		b.emitDuration(ctx, event.Name, opts, event.Duration)
+	}
+}
+
 // EmitInt implements EmitterBackend.EmitInt
 func (b *OtelBackend) EmitInt(ctx context.Context, event string, props map[string]interface{}, value int64, metricType t.MetricType) {
-	attrs := propsToAttributes(props)
-	opts := metric.WithAttributes(attrs...)
+	rate, _ := sampledRate(props)
+	b.emitInt(ctx, event, metric.WithAttributes(propsToAttributes(props)...), value, metricType, rate)
+}
 
+// EmitFloat implements EmitterBackend.EmitFloat
+func (b *OtelBackend) EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType t.MetricType) {
+	rate, _ := sampledRate(props)
+	b.emitFloat(ctx, event, metric.WithAttributes(propsToAttributes(props)...), value, metricType, rate)
+}
+
+// EmitDuration implements EmitterBackend.EmitDuration
+func (b *OtelBackend) EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType t.MetricType) {
+	b.emitDuration(ctx, event, metric.WithAttributes(propsToAttributes(props)...), value)
+}
+
+// emitInt records value; counters are scaled up by 1/rate for sampled events
+func (b *OtelBackend) emitInt(ctx context.Context, event string, opts metric.MeasurementOption, value int64, metricType t.MetricType, rate float64) {
 	switch metricType {
 	case t.COUNT, t.METER:
 		counter, err := b.getOrCreateInt64Counter(event)
 		if err != nil {
+			b.reportError(ctx, event, err)
 			return
 		}
+		if rate < 1 {
+			value = int64(math.Round(float64(value) / rate))
+		}
 		counter.Add(ctx, value, opts)
 
 	case t.GAUGE:
 		gauge, err := b.getOrCreateInt64Gauge(event)
 		if err != nil {
+			b.reportError(ctx, event, err)
 			return
 		}
 		gauge.Record(ctx, value, opts)
@@ -107,36 +249,34 @@ func (b *OtelBackend) EmitInt(ctx context.Context, event string, props map[strin
 	case t.HISTOGRAM:
 		histogram, err := b.getOrCreateInt64Histogram(event)
 		if err != nil {
+			b.reportError(ctx, event, err)
 			return
 		}
 		histogram.Record(ctx, value, opts)
 
 	case t.TIMER:
-		// For timer with int64, treat as milliseconds and convert to histogram
-		histogram, err := b.getOrCreateFloat64Histogram(event)
-		if err != nil {
-			return
-		}
-		histogram.Record(ctx, float64(value)/1000.0, opts) // Convert ms to seconds
+		// For timer with int64, treat as milliseconds and record as a duration
+		b.emitDuration(ctx, event, opts, time.Duration(value)*time.Millisecond)
 	}
 }
 
-// EmitFloat implements EmitterBackend.EmitFloat
-func (b *OtelBackend) EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType t.MetricType) {
-	attrs := propsToAttributes(props)
-	opts := metric.WithAttributes(attrs...)
-
+func (b *OtelBackend) emitFloat(ctx context.Context, event string, opts metric.MeasurementOption, value float64, metricType t.MetricType, rate float64) {
 	switch metricType {
 	case t.COUNT, t.METER:
 		counter, err := b.getOrCreateFloat64Counter(event)
 		if err != nil {
+			b.reportError(ctx, event, err)
 			return
 		}
+		if rate < 1 {
+			value = value / rate
+		}
 		counter.Add(ctx, value, opts)
 
 	case t.GAUGE:
 		gauge, err := b.getOrCreateFloat64Gauge(event)
 		if err != nil {
+			b.reportError(ctx, event, err)
 			return
 		}
 		gauge.Record(ctx, value, opts)
@@ -144,23 +284,26 @@ func (b *OtelBackend) EmitFloat(ctx context.Context, event string, props map[str
 	case t.HISTOGRAM, t.TIMER:
 		histogram, err := b.getOrCreateFloat64Histogram(event)
 		if err != nil {
+			b.reportError(ctx, event, err)
 			return
 		}
 		histogram.Record(ctx, value, opts)
 	}
 }
 
-// EmitDuration implements EmitterBackend.EmitDuration
-func (b *OtelBackend) EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType t.MetricType) {
-	attrs := propsToAttributes(props)
-	opts := metric.WithAttributes(attrs...)
-
-	// Record duration as seconds (float64) in a histogram
+func (b *OtelBackend) emitDuration(ctx context.Context, event string, opts metric.MeasurementOption, value time.Duration) {
+	// Record duration as seconds (float64) in a histogram, or in the
+	// instrument's unit if that is a fraction of a second
 	histogram, err := b.getOrCreateFloat64Histogram(event)
 	if err != nil {
+		b.reportError(ctx, event, err)
 		return
 	}
-	histogram.Record(ctx, value.Seconds(), opts)
+	scale := 1.0
+	if v, ok := b.durationScales.Load(event); ok {
+		scale = v.(float64)
+	}
+	histogram.Record(ctx, value.Seconds()*scale, opts)
 }
 
 // Instrument cache getters/creators
@@ -172,7 +315,8 @@ func (b *OtelBackend) getOrCreateInt64Counter(name string) (metric.Int64Counter,
 	}
 
 	// Create new counter
-	counter, err := b.meter.Int64Counter(name)
+	description, unit := b.instrumentInfo(name)
+	counter, err := b.meter.Int64Counter(name, description, unit)
 	if err != nil {
 		return nil, err
 	}
@@ -189,7 +333,8 @@ func (b *OtelBackend) getOrCreateFloat64Counter(name string) (metric.Float64Coun
 	}
 
 	// Create new counter
-	counter, err := b.meter.Float64Counter(name)
+	description, unit := b.instrumentInfo(name)
+	counter, err := b.meter.Float64Counter(name, description, unit)
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +351,8 @@ func (b *OtelBackend) getOrCreateInt64Gauge(name string) (metric.Int64Gauge, err
 	}
 
 	// Create new gauge
-	gauge, err := b.meter.Int64Gauge(name)
+	description, unit := b.instrumentInfo(name)
+	gauge, err := b.meter.Int64Gauge(name, description, unit)
 	if err != nil {
 		return nil, err
 	}
@@ -223,7 +369,8 @@ func (b *OtelBackend) getOrCreateFloat64Gauge(name string) (metric.Float64Gauge,
 	}
 
 	// Create new gauge
-	gauge, err := b.meter.Float64Gauge(name)
+	description, unit := b.instrumentInfo(name)
+	gauge, err := b.meter.Float64Gauge(name, description, unit)
 	if err != nil {
 		return nil, err
 	}
@@ -240,7 +387,12 @@ func (b *OtelBackend) getOrCreateInt64Histogram(name string) (metric.Int64Histog
 	}
 
 	// Create new histogram
-	histogram, err := b.meter.Int64Histogram(name)
+	c := b.instrumentConfig(name)
+	opts := []metric.Int64HistogramOption{metric.WithDescription(c.Description), metric.WithUnit(c.Unit)}
+	if buckets := c.explicitBuckets(); buckets != nil {
+		opts = append(opts, metric.WithExplicitBucketBoundaries(buckets...))
+	}
+	histogram, err := b.meter.Int64Histogram(name, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -257,10 +409,16 @@ func (b *OtelBackend) getOrCreateFloat64Histogram(name string) (metric.Float64Hi
 	}
 
 	// Create new histogram
-	histogram, err := b.meter.Float64Histogram(name)
+	c := b.instrumentConfig(name)
+	opts := []metric.Float64HistogramOption{metric.WithDescription(c.Description), metric.WithUnit(c.Unit)}
+	if buckets := c.explicitBuckets(); buckets != nil {
+		opts = append(opts, metric.WithExplicitBucketBoundaries(buckets...))
+	}
+	histogram, err := b.meter.Float64Histogram(name, opts...)
 	if err != nil {
 		return nil, err
 	}
+	b.durationScales.LoadOrStore(name, durationScale(c.Unit))
 
 	// Atomically store or get existing (if another goroutine created it first)
 	actual, _ := b.float64Histograms.LoadOrStore(name, histogram)
@@ -40,6 +40,24 @@ var _ = Describe("OpenTelemetry Backend", func() {
 			emitter := emit.NewEmitter(backend)
 			Expect(emitter).NotTo(BeNil())
 		})
+
+		It("should create instruments with the registered description and unit", func() {
+			emitter := emit.NewEmitter(backend)
+			emitter.Metric("cache.latency", t.HISTOGRAM, emit.WithDescription("Cache lookup latency"), emit.WithUnit("ms"))
+			emitter.Metric("cache.hits", t.COUNT)
+
+			var rm metricdata.ResourceMetrics
+			Expect(reader.Collect(ctx, &rm)).To(Succeed())
+
+			described := make(map[string][2]string)
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				described[m.Name] = [2]string{m.Description, m.Unit}
+			}
+			Expect(described).To(Equal(map[string][2]string{
+				"cache.latency": {"Cache lookup latency", "ms"},
+				"cache.hits":    {"", ""},
+			}))
+		})
 	})
 
 	Context("EmitInt", func() {
@@ -62,6 +80,52 @@ var _ = Describe("OpenTelemetry Backend", func() {
 			Expect(sum.DataPoints[0].Value).To(Equal(int64(5)))
 		})
 
+		It("should record events", func() {
+			backend.Emit(ctx, &t.Event{Name: "test.counter", MetricType: t.COUNT, ValueKind: t.IntValue, Int: 5, SampleRate: 0.5, Props: map[string]interface{}{"host": "a"}})
+
+			var rm metricdata.ResourceMetrics
+			Expect(reader.Collect(ctx, &rm)).To(Succeed())
+
+			sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+			Expect(ok).To(BeTrue())
+			Expect(sum.DataPoints[0].Value).To(Equal(int64(10)))
+			Expect(sum.DataPoints[0].Attributes.Len()).To(Equal(1))
+		})
+
+		It("should record attrs with their types", func() {
+			backend.Emit(ctx, &t.Event{Name: "test.counter", MetricType: t.COUNT, ValueKind: t.IntValue, Int: 1, Props: map[string]interface{}{"host": "a"}, Attrs: []t.Attr{
+				{Key: "host", Kind: t.AttrString, Str: "b"},
+				{Key: "status", Kind: t.AttrInt64, Num: 200},
+				{Key: "cached", Kind: t.AttrBool, Num: 1},
+			}})
+
+			var rm metricdata.ResourceMetrics
+			Expect(reader.Collect(ctx, &rm)).To(Succeed())
+
+			sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+			Expect(ok).To(BeTrue())
+			attrs := sum.DataPoints[0].Attributes
+			Expect(attrs.Len()).To(Equal(3))
+			host, _ := attrs.Value("host")
+			Expect(host.AsString()).To(Equal("b"))
+			status, _ := attrs.Value("status")
+			Expect(status.AsInt64()).To(Equal(int64(200)))
+			cached, _ := attrs.Value("cached")
+			Expect(cached.AsBool()).To(BeTrue())
+		})
+
+		It("should scale counters sampled by the emitter", func() {
+			backend.EmitInt(ctx, "test.counter", map[string]interface{}{"_rate": 0.25, "_sampled": true}, 5, t.COUNT)
+
+			var rm metricdata.ResourceMetrics
+			Expect(reader.Collect(ctx, &rm)).To(Succeed())
+
+			sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+			Expect(ok).To(BeTrue())
+			Expect(sum.DataPoints[0].Value).To(Equal(int64(20)))
+			Expect(sum.DataPoints[0].Attributes.Len()).To(BeZero())
+		})
+
 		It("should emit a gauge", func() {
 			backend.EmitInt(ctx, "test.gauge", map[string]interface{}{}, 42, t.GAUGE)
 
@@ -286,4 +350,105 @@ var _ = Describe("OpenTelemetry Backend", func() {
 			Expect(hist.DataPoints[0].Sum).To(Equal(2.0)) // 2000ms = 2s
 		})
 	})
+
+	Context("Lifecycle", func() {
+		It("should be a no-op without a provider", func() {
+			Expect(backend.Flush(ctx)).To(Succeed())
+			Expect(backend.Close(ctx)).To(Succeed())
+		})
+
+		It("should flush and shut down the attached provider", func() {
+			emitter := emit.NewEmitter(backend.WithProvider(mp))
+			emitter.Count(ctx, "test.counter", nil, 1)
+
+			Expect(emitter.Flush(ctx)).To(Succeed())
+			var rm metricdata.ResourceMetrics
+			Expect(reader.Collect(ctx, &rm)).To(Succeed())
+
+			Expect(emitter.Shutdown(ctx)).To(Succeed())
+			Expect(reader.Collect(ctx, &rm)).NotTo(Succeed())
+		})
+	})
+
+	Context("Instrument configuration", func() {
+		histogram := func(rm metricdata.ResourceMetrics, name string) metricdata.Metrics {
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				if m.Name == name {
+					return m
+				}
+			}
+			Fail("no metric " + name)
+			return metricdata.Metrics{}
+		}
+
+		It("should use buckets from registration options", func() {
+			backend.Describe("cache.latency", t.HISTOGRAM, t.EventInfo{Buckets: []float64{0.0001, 0.0005, 0.001}})
+			backend.EmitFloat(ctx, "cache.latency", nil, 0.00005, t.HISTOGRAM)
+			backend.EmitFloat(ctx, "cache.latency", nil, 0.0003, t.HISTOGRAM)
+
+			var rm metricdata.ResourceMetrics
+			Expect(reader.Collect(ctx, &rm)).To(Succeed())
+
+			hist := histogram(rm, "cache.latency").Data.(metricdata.Histogram[float64])
+			Expect(hist.DataPoints[0].Bounds).To(Equal([]float64{0.0001, 0.0005, 0.001}))
+			Expect(hist.DataPoints[0].BucketCounts).To(Equal([]uint64{1, 1, 0, 0}))
+		})
+
+		It("should let the backend configuration override registration options", func() {
+			backend.WithInstruments(map[string]otel.InstrumentConfig{
+				"cache.latency": {Unit: "ms", Description: "Cache latency", Buckets: []float64{0.1, 0.5, 1}},
+			})
+			emitter := emit.NewEmitter(backend)
+			emitter.Metric("cache.latency", t.TIMER, emit.WithUnit("s"), emit.WithBuckets(1, 2))
+			emitter.EmitDuration(ctx, "cache.latency", nil, 300*time.Microsecond, t.TIMER)
+
+			var rm metricdata.ResourceMetrics
+			Expect(reader.Collect(ctx, &rm)).To(Succeed())
+
+			m := histogram(rm, "cache.latency")
+			Expect(m.Unit).To(Equal("ms"))
+			Expect(m.Description).To(Equal("Cache latency"))
+			hist := m.Data.(metricdata.Histogram[float64])
+			Expect(hist.DataPoints[0].Bounds).To(Equal([]float64{0.1, 0.5, 1}))
+			// Durations are recorded in milliseconds
+			Expect(hist.DataPoints[0].Sum).To(BeNumerically("~", 0.3, 1e-9))
+		})
+
+		It("should build exponential histogram views", func() {
+			instruments := map[string]otel.InstrumentConfig{
+				"rpc.latency": {Exponential: &otel.ExponentialHistogram{MaxSize: 20}},
+				"db.latency":  {Buckets: []float64{1, 2}},
+			}
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithView(otel.Views(instruments)...))
+			defer mp.Shutdown(ctx)
+			backend := otel.NewOtelBackend(mp.Meter("test-meter")).WithInstruments(instruments)
+
+			backend.EmitDuration(ctx, "rpc.latency", nil, time.Second, t.TIMER)
+			backend.EmitDuration(ctx, "db.latency", nil, time.Second, t.TIMER)
+
+			var rm metricdata.ResourceMetrics
+			Expect(reader.Collect(ctx, &rm)).To(Succeed())
+
+			exp, ok := histogram(rm, "rpc.latency").Data.(metricdata.ExponentialHistogram[float64])
+			Expect(ok).To(BeTrue())
+			Expect(exp.DataPoints[0].Count).To(Equal(uint64(1)))
+			_, ok = histogram(rm, "db.latency").Data.(metricdata.Histogram[float64])
+			Expect(ok).To(BeTrue())
+			Expect(otel.Views(instruments)).To(HaveLen(1))
+		})
+	})
+
+	Context("Error reporting", func() {
+		It("should report instrument creation failures to the emitter", func() {
+			var handled error
+			emitter := emit.NewEmitter(backend).WithErrorHandler(func(_ context.Context, err error) {
+				handled = err
+			})
+			emitter.Count(ctx, "not a valid instrument name!", nil, 1)
+
+			Expect(handled).To(HaveOccurred())
+			Expect(emitter.Stats().Errors).To(Equal(uint64(1)))
+		})
+	})
 })
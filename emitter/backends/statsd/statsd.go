@@ -4,7 +4,9 @@ package statsd
 import (
 	"context"
 	"fmt"
+	"io"
 	"maps"
+	"math"
 	"regexp"
 	"sort"
 	"strings"
@@ -24,7 +26,8 @@ type StatsdClient interface {
 }
 
 type StatsdBackend struct {
-	client StatsdClient
+	client  StatsdClient
+	onError t.ErrorHandler
 }
 
 func NewStatsdBackend(client StatsdClient) *StatsdBackend {
@@ -33,12 +36,38 @@ func NewStatsdBackend(client StatsdClient) *StatsdBackend {
 	}
 }
 
+// SetErrorHandler satisfies the t.ErrorReporter interface. The handler receives
+// the errors returned by the statsd client.
+func (b *StatsdBackend) SetErrorHandler(handler t.ErrorHandler) {
+	b.onError = handler
+}
+
+func (b *StatsdBackend) reportError(ctx context.Context, event string, err error) {
+	if err != nil && b.onError != nil {
+		b.onError(ctx, event, err)
+	}
+}
+
 func cleanEventName(event string) string {
 	alnum := regexp.MustCompile("[^[:alnum:]]")
 	us := regexp.MustCompile("_+")
 	return strings.ToLower(us.ReplaceAllLiteralString(alnum.ReplaceAllLiteralString(event, "_"), "_"))
 }
 
+// sampledRate returns the rate of an event that the emitter already sampled.
+// The client must not sample those events again, so they are sent with a rate
+// of 1 and counters are scaled up instead.
+func sampledRate(props map[string]interface{}) (float64, bool) {
+	if _, ok := props["_sampled"]; !ok {
+		return 1, false
+	}
+	rate := cast.ToFloat64(props["_rate"])
+	if rate <= 0 || rate > 1 {
+		return 1, false
+	}
+	return rate, true
+}
+
 func propsToTags(props map[string]interface{}) (float32, []statsd.Tag) {
 	p := maps.Clone(props)
 	rval, found := p["_rate"]
@@ -47,6 +76,10 @@ func propsToTags(props map[string]interface{}) (float32, []statsd.Tag) {
 		rate = cast.ToFloat32(rval)
 		delete(p, "_rate")
 	}
+	if _, sampled := p["_sampled"]; sampled {
+		rate = 1.0
+		delete(p, "_sampled")
+	}
 	delete(p, "_message")
 	delete(p, "_logLevel")
 
@@ -65,39 +98,116 @@ func propsToTags(props map[string]interface{}) (float32, []statsd.Tag) {
 	return rate, tags
 }
 
+// eventTags returns the tags for the event's props and attrs, sorted by key.
+// Attrs win over props with the same key.
+func eventTags(event *t.Event) (float32, []statsd.Tag) {
+	if len(event.Attrs) == 0 {
+		return propsToTags(event.Props)
+	}
+
+	props := maps.Clone(event.Props)
+	for _, a := range event.Attrs {
+		delete(props, a.Key)
+	}
+	rate, tags := propsToTags(props)
+	for _, a := range event.Attrs {
+		tags = append(tags, statsd.Tag{cleanEventName(a.Key), cleanEventName(a.String())})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i][0] < tags[j][0] })
+	return rate, tags
+}
+
+// Emit satisfies the t.EventBackend interface. Events the emitter sampled are
+// sent with a rate of 1 and counters are scaled up instead, so the client
+// does not sample them again. Log events are counted.
+func (b *StatsdBackend) Emit(ctx context.Context, event *t.Event) {
+	rate, tags := eventTags(event)
+	scale := 1.0
+	if event.Sampled() {
+		rate = 1.0
+		scale = event.SampleRate
+	}
+
+	switch event.ValueKind {
+	case t.IntValue:
+		b.emitInt(ctx, event.Name, event.Int, event.MetricType, rate, scale, tags)
+	case t.FloatValue:
+		b.emitFloat(ctx, event.Name, event.Float, event.MetricType, rate, tags)
+	case t.DurationValue:
+		b.emitDuration(ctx, event.Name, event.Duration, event.MetricType, rate, tags)
+	}
+}
+
 // satisfy the t.EmitterBackend interface by implementing the EmitInt method
 func (b *StatsdBackend) EmitInt(ctx context.Context, event string, props map[string]interface{}, value int64, metricType t.MetricType) {
 	rate, tags := propsToTags(props)
+	scale, _ := sampledRate(props)
+	b.emitInt(ctx, event, value, metricType, rate, scale, tags)
+}
 
+// satisfy the t.EmitterBackend interface by implementing the EmitFloat method
+func (b *StatsdBackend) EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType t.MetricType) {
+	rate, tags := propsToTags(props)
+	b.emitFloat(ctx, event, value, metricType, rate, tags)
+}
+
+// satisfy the t.EmitterBackend interface by implementing the EmitDuration method
+func (b *StatsdBackend) EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType t.MetricType) {
+	rate, tags := propsToTags(props)
+	b.emitDuration(ctx, event, value, metricType, rate, tags)
+}
+
+// emitInt sends value to the client; counters are scaled up by 1/scale
+func (b *StatsdBackend) emitInt(ctx context.Context, event string, value int64, metricType t.MetricType, rate float32, scale float64, tags []statsd.Tag) {
+	var err error
 	switch metricType {
 	case t.GAUGE:
-		b.client.Gauge(event, value, rate, tags...)
+		err = b.client.Gauge(event, value, rate, tags...)
 	case t.COUNT:
-		b.client.Inc(event, value, rate, tags...)
+		if scale < 1 {
+			value = int64(math.Round(float64(value) / scale))
+		}
+		err = b.client.Inc(event, value, rate, tags...)
 	case t.TIMER:
-		b.client.Timing(event, value, rate, tags...)
+		err = b.client.Timing(event, value, rate, tags...)
 	case t.HISTOGRAM:
-		b.client.Gauge(event, value, rate, tags...)
+		err = b.client.Gauge(event, value, rate, tags...)
 	}
+	b.reportError(ctx, event, err)
 }
 
-// satisfy the t.EmitterBackend interface by implementing the EmitFloat method
-func (b *StatsdBackend) EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType t.MetricType) {
-	rate, tags := propsToTags(props)
-
+func (b *StatsdBackend) emitFloat(ctx context.Context, event string, value float64, metricType t.MetricType, rate float32, tags []statsd.Tag) {
+	var err error
 	switch metricType {
 	case t.GAUGE:
-		b.client.Gauge(event, int64(value*10000), rate, tags...)
+		err = b.client.Gauge(event, int64(value*10000), rate, tags...)
 	case t.HISTOGRAM:
-		b.client.Gauge(event, int64(value*10000), rate, tags...)
+		err = b.client.Gauge(event, int64(value*10000), rate, tags...)
 	}
+	b.reportError(ctx, event, err)
 }
 
-// satisfy the t.EmitterBackend interface by implementing the EmitDuration method
-func (b *StatsdBackend) EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType t.MetricType) {
-	rate, tags := propsToTags(props)
+func (b *StatsdBackend) emitDuration(ctx context.Context, event string, value time.Duration, metricType t.MetricType, rate float32, tags []statsd.Tag) {
 	switch metricType {
 	case t.TIMER:
-		b.client.TimingDuration(event, value, rate, tags...)
+		b.reportError(ctx, event, b.client.TimingDuration(event, value, rate, tags...))
+	}
+}
+
+// Flush satisfies the t.Flusher interface for clients that buffer packets and
+// expose a Flush method. It is a no-op for other clients.
+func (b *StatsdBackend) Flush(ctx context.Context) error {
+	if f, ok := b.client.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close satisfies the t.Closer interface. Clients that implement io.Closer,
+// such as statsd.Client, are closed, which also flushes any buffered packets.
+func (b *StatsdBackend) Close(ctx context.Context) error {
+	if c, ok := b.client.(io.Closer); ok {
+		return c.Close()
 	}
+	return nil
 }
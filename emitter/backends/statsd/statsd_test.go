@@ -2,6 +2,7 @@ package statsd
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/cactus/go-statsd-client/v5/statsd"
@@ -63,6 +64,43 @@ var _ = Describe("Statsd", func() {
 		statsdBackend.EmitInt(context.Background(), "foo", map[string]interface{}{"_rate": 2.0}, 5, t.COUNT)
 	})
 
+	It("should not sample events the emitter already sampled", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		mockStatsdClient := mocks.NewMockStatsdClient(ctrl)
+		mockStatsdClient.EXPECT().Inc("foo", int64(20), float32(1.0)).Return(nil)
+		mockStatsdClient.EXPECT().Timing("foo", int64(5), float32(1.0)).Return(nil)
+		statsdBackend := NewStatsdBackend(mockStatsdClient)
+		props := map[string]interface{}{"_rate": 0.25, "_sampled": true}
+		statsdBackend.EmitInt(context.Background(), "foo", props, 5, t.COUNT)
+		statsdBackend.EmitInt(context.Background(), "foo", props, 5, t.TIMER)
+	})
+
+	It("should emit events", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		mockStatsdClient := mocks.NewMockStatsdClient(ctrl)
+		mockStatsdClient.EXPECT().Inc("foo", int64(20), float32(1.0), statsd.Tag{"host", "a"}).Return(nil)
+		mockStatsdClient.EXPECT().TimingDuration("bar", time.Second, float32(1.0)).Return(nil)
+		mockStatsdClient.EXPECT().Inc("log", int64(1), float32(1.0)).Return(nil)
+		statsdBackend := NewStatsdBackend(mockStatsdClient)
+		statsdBackend.Emit(context.Background(), &t.Event{Name: "foo", MetricType: t.COUNT, ValueKind: t.IntValue, Int: 5, SampleRate: 0.25, Props: map[string]interface{}{"host": "a"}})
+		statsdBackend.Emit(context.Background(), &t.Event{Name: "bar", MetricType: t.TIMER, ValueKind: t.DurationValue, Duration: time.Second})
+		statsdBackend.Emit(context.Background(), &t.Event{Kind: t.LogEvent, Name: "log", MetricType: t.COUNT, ValueKind: t.IntValue, Int: 1, Level: t.LevelInfo, Message: "msg"})
+	})
+
+	It("should tag events with their attrs", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		mockStatsdClient := mocks.NewMockStatsdClient(ctrl)
+		mockStatsdClient.EXPECT().Inc("foo", int64(1), float32(1.0), statsd.Tag{"host", "b"}, statsd.Tag{"region", "eu"}, statsd.Tag{"status", "200"}).Return(nil)
+		statsdBackend := NewStatsdBackend(mockStatsdClient)
+		statsdBackend.Emit(context.Background(), &t.Event{Name: "foo", MetricType: t.COUNT, ValueKind: t.IntValue, Int: 1, Props: map[string]interface{}{"host": "a", "region": "eu"}, Attrs: []t.Attr{
+			{Key: "status", Kind: t.AttrInt64, Num: 200},
+			{Key: "host", Kind: t.AttrString, Str: "b"},
+		}})
+	})
+
 	It("should emit a gauge", func() {
 		ctrl := gomock.NewController(GinkgoT())
 		defer ctrl.Finish()
@@ -90,3 +128,61 @@ var _ = Describe("Statsd", func() {
 		statsdBackend.EmitInt(context.Background(), "foo", map[string]interface{}{"_rate": 2.0, "Hello": "World", "Handled unwanted ... chars": "value####too"}, 5, t.HISTOGRAM)
 	})
 })
+
+// closingClient is a StatsdClient that records Flush and Close calls
+type closingClient struct {
+	*mocks.MockStatsdClient
+	flushed bool
+	closed  bool
+}
+
+func (c *closingClient) Flush() error {
+	c.flushed = true
+	return nil
+}
+
+func (c *closingClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+var _ = Describe("Lifecycle", func() {
+	It("should flush and close clients that support it", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		client := &closingClient{MockStatsdClient: mocks.NewMockStatsdClient(ctrl)}
+		statsdBackend := NewStatsdBackend(client)
+
+		Expect(emit.NewEmitter(statsdBackend).Shutdown(context.Background())).To(Succeed())
+		Expect(client.flushed).To(BeTrue())
+		Expect(client.closed).To(BeTrue())
+	})
+
+	It("should ignore clients without Flush or Close", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		statsdBackend := NewStatsdBackend(mocks.NewMockStatsdClient(ctrl))
+
+		Expect(statsdBackend.Flush(context.Background())).To(Succeed())
+		Expect(statsdBackend.Close(context.Background())).To(Succeed())
+	})
+})
+
+var _ = Describe("Error reporting", func() {
+	It("should report client errors to the emitter", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		clientErr := errors.New("write: connection refused")
+		mockStatsdClient := mocks.NewMockStatsdClient(ctrl)
+		mockStatsdClient.EXPECT().Inc("foo", int64(1), float32(1.0)).Return(clientErr)
+
+		var handled error
+		emitter := emit.NewEmitter(NewStatsdBackend(mockStatsdClient)).WithErrorHandler(func(_ context.Context, err error) {
+			handled = err
+		})
+		emitter.Count(context.Background(), "foo", nil, 1)
+
+		Expect(handled).To(MatchError(clientErr))
+		Expect(emitter.Stats().Errors).To(Equal(uint64(1)))
+	})
+})
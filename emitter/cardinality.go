@@ -0,0 +1,197 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// CardinalityOverflowValue replaces the prop values of a metric event whose
+// combination of values would take the event over its cardinality limit, so
+// that all new combinations share a single series.
+const CardinalityOverflowValue = "__overflow__"
+
+// CardinalityLimitEvent is the WARN log emitted the first time an event goes
+// over its cardinality limit. It carries "event" and "limit" props.
+const CardinalityLimitEvent = "emitter.cardinality.limit"
+
+// cardinalityLimiter tracks the distinct prop value combinations of metric
+// events. It is shared with sub-emitters, since they share backends.
+type cardinalityLimiter struct {
+	mu sync.Mutex
+	// limit applies to events without an entry in limits; 0 means unlimited
+	limit  int
+	limits map[string]int
+	events map[string]*cardinalitySet
+}
+
+type cardinalitySet struct {
+	seen   map[string]struct{}
+	warned bool
+}
+
+func newCardinalityLimiter() *cardinalityLimiter {
+	return &cardinalityLimiter{limits: make(map[string]int), events: make(map[string]*cardinalitySet)}
+}
+
+func (c *cardinalityLimiter) limitFor(event string) int {
+	if limit, ok := c.limits[event]; ok {
+		return limit
+	}
+	return c.limit
+}
+
+// combination identifies an event's prop values. Attrs win over props with
+// the same key, as they do for backends.
+func combination(ev *t.Event) string {
+	values := make(map[string]string, len(ev.Props)+len(ev.Attrs))
+	for k, v := range ev.Props {
+		values[k] = fmt.Sprintf("%v", v)
+	}
+	for _, a := range ev.Attrs {
+		values[a.Key] = a.String()
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(values[k])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// check records the event's combination of prop values. It reports whether
+// the combination is over the limit and, the first time that happens for the
+// event, the limit to warn about.
+func (c *cardinalityLimiter) check(ev *t.Event) (overflow bool, warnLimit int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	limit := c.limitFor(ev.Name)
+	if limit <= 0 {
+		return false, 0
+	}
+	set, ok := c.events[ev.Name]
+	if !ok {
+		set = &cardinalitySet{seen: make(map[string]struct{})}
+		c.events[ev.Name] = set
+	}
+
+	key := combination(ev)
+	if _, ok := set.seen[key]; ok {
+		return false, 0
+	}
+	if len(set.seen) < limit {
+		set.seen[key] = struct{}{}
+		return false, 0
+	}
+	if set.warned {
+		return true, 0
+	}
+	set.warned = true
+	return true, limit
+}
+
+// cardinality returns the number of distinct combinations recorded for event
+// and its limit.
+func (c *cardinalityLimiter) cardinality(event string) (int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	limit := c.limitFor(event)
+	if limit <= 0 {
+		return 0, 0
+	}
+	n := 0
+	if set, ok := c.events[event]; ok {
+		n = len(set.seen)
+	}
+	return n, limit
+}
+
+// overflow rewrites every prop and attr value of ev to CardinalityOverflowValue.
+// The event's map and attrs may belong to the caller, so both are replaced.
+func overflow(ev *t.Event) {
+	props := make(map[string]interface{}, len(ev.Props))
+	for k := range ev.Props {
+		props[k] = CardinalityOverflowValue
+	}
+	ev.Props = props
+	if len(ev.Attrs) > 0 {
+		attrs := make([]t.Attr, len(ev.Attrs))
+		for i, a := range ev.Attrs {
+			attrs[i] = String(a.Key, CardinalityOverflowValue)
+		}
+		ev.Attrs = attrs
+	}
+}
+
+// limitCardinality applies the cardinality limit to a metric event whose props
+// have been computed.
+func (e *Emitter) limitCardinality(ctx context.Context, ev *t.Event) {
+	over, warnLimit := e.cardinality.check(ev)
+	if !over {
+		return
+	}
+	overflow(ev)
+	if warnLimit > 0 {
+		e.emitLog(ctx, CardinalityLimitEvent, map[string]interface{}{"event": ev.Name, "limit": warnLimit}, t.LevelWarn,
+			fmt.Sprintf("event %s exceeded its cardinality limit of %d; new prop values are reported as %s", ev.Name, warnLimit, CardinalityOverflowValue))
+	}
+}
+
+func (e *Emitter) ensureCardinalityLimiter() *cardinalityLimiter {
+	if e.cardinality == nil {
+		e.cardinality = newCardinalityLimiter()
+	}
+	return e.cardinality
+}
+
+// WithCardinalityLimit caps the number of distinct prop value combinations of
+// every metric event at max. Once an event reaches the cap, new combinations
+// have all their values replaced by CardinalityOverflowValue and a
+// CardinalityLimitEvent warning is logged, once per event. Log events are not
+// limited. A max of 0 removes the cap.
+func (e *Emitter) WithCardinalityLimit(max int) *Emitter {
+	c := e.ensureCardinalityLimiter()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limit = max
+	return e
+}
+
+// WithEventCardinalityLimit caps the distinct prop value combinations of a
+// single metric event, overriding WithCardinalityLimit. A max of 0 exempts
+// the event. On a sub-emitter, event is prefixed like the events it emits.
+func (e *Emitter) WithEventCardinalityLimit(event string, max int) *Emitter {
+	c := e.ensureCardinalityLimiter()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limits[e.eventName(event)] = max
+	return e
+}
+
+// Cardinality returns the number of distinct prop value combinations seen for
+// event and its limit, or zeros if no limit applies. On a sub-emitter, event
+// is prefixed like the events it emits.
+func (e *Emitter) Cardinality(event string) (current int, limit int) {
+	return e.cardinalityOf(e.eventName(event))
+}
+
+// cardinalityOf is Cardinality for an event's full name.
+func (e *Emitter) cardinalityOf(event string) (current int, limit int) {
+	if e.cardinality == nil {
+		return 0, 0
+	}
+	return e.cardinality.cardinality(event)
+}
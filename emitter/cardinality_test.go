@@ -0,0 +1,126 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+var _ = Describe("Cardinality limits", func() {
+	var ctx context.Context
+	var recorder *eventRecorder
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &eventRecorder{}
+	})
+
+	metrics := func(name string) []Event {
+		var events []Event
+		for _, ev := range recorder.Events() {
+			if ev.Name == name {
+				events = append(events, ev)
+			}
+		}
+		return events
+	}
+
+	It("Should rewrite new combinations past the limit to the overflow value", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).WithCardinalityLimit(2)
+
+		for i := 0; i < 4; i++ {
+			emitter.Count(ctx, "logins", map[string]interface{}{"user": fmt.Sprintf("u%d", i), "region": "eu"}, 1)
+		}
+		// Combinations seen before the limit was reached keep their values
+		emitter.Count(ctx, "logins", map[string]interface{}{"region": "eu", "user": "u1"}, 1)
+
+		events := metrics("logins")
+		Expect(events).To(HaveLen(5))
+		Expect(events[1].Props).To(Equal(map[string]interface{}{"user": "u1", "region": "eu"}))
+		Expect(events[2].Props).To(Equal(map[string]interface{}{"user": CardinalityOverflowValue, "region": CardinalityOverflowValue}))
+		Expect(events[3].Props).To(Equal(map[string]interface{}{"user": CardinalityOverflowValue, "region": CardinalityOverflowValue}))
+		Expect(events[4].Props).To(Equal(map[string]interface{}{"user": "u1", "region": "eu"}))
+
+		current, limit := emitter.Cardinality("logins")
+		Expect(current).To(Equal(2))
+		Expect(limit).To(Equal(2))
+	})
+
+	It("Should warn once per event", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).WithCardinalityLimit(1)
+
+		for i := 0; i < 3; i++ {
+			emitter.Count(ctx, "a", map[string]interface{}{"id": i}, 1)
+			emitter.Count(ctx, "b", map[string]interface{}{"id": i}, 1)
+		}
+
+		warnings := metrics(CardinalityLimitEvent)
+		Expect(warnings).To(HaveLen(2))
+		Expect(warnings[0].Kind).To(Equal(LogEvent))
+		Expect(warnings[0].Level).To(Equal(LevelWarn))
+		Expect(warnings[0].Props).To(Equal(map[string]interface{}{"event": "a", "limit": 1}))
+		Expect(warnings[1].Props).To(HaveKeyWithValue("event", "b"))
+	})
+
+	It("Should limit attrs and leave the caller's attrs alone", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).WithCardinalityLimit(1)
+
+		emitter.CountAttrs(ctx, "requests", 1, String("route", "/a"))
+		attrs := []Attr{String("route", "/b"), Int("status", 200)}
+		emitter.CountAttrs(ctx, "requests", 1, attrs...)
+
+		events := metrics("requests")
+		Expect(events[1].Attrs).To(Equal([]Attr{String("route", CardinalityOverflowValue), String("status", CardinalityOverflowValue)}))
+		Expect(attrs).To(Equal([]Attr{String("route", "/b"), Int("status", 200)}))
+	})
+
+	It("Should prefer event limits over the global limit", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).
+			WithCardinalityLimit(1).
+			WithEventCardinalityLimit("wide", 3).
+			WithEventCardinalityLimit("exempt", 0)
+
+		for i := 0; i < 3; i++ {
+			emitter.Count(ctx, "wide", map[string]interface{}{"id": i}, 1)
+			emitter.Count(ctx, "exempt", map[string]interface{}{"id": i}, 1)
+		}
+
+		for _, ev := range append(metrics("wide"), metrics("exempt")...) {
+			Expect(ev.Props["id"]).NotTo(Equal(CardinalityOverflowValue))
+		}
+		current, limit := emitter.Cardinality("exempt")
+		Expect(current).To(BeZero())
+		Expect(limit).To(BeZero())
+		Expect(metrics(CardinalityLimitEvent)).To(BeEmpty())
+	})
+
+	It("Should count context props and not limit logs", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).WithCardinalityLimit(1)
+
+		emitter.Count(ContextWithProps(ctx, map[string]interface{}{"tenant": "a"}), "event", nil, 1)
+		emitter.Count(ContextWithProps(ctx, map[string]interface{}{"tenant": "b"}), "event", nil, 1)
+		emitter.Info("log", map[string]interface{}{"id": 1}, "msg")
+		emitter.Info("log", map[string]interface{}{"id": 2}, "msg")
+
+		Expect(metrics("event")[1].Props).To(Equal(map[string]interface{}{"tenant": CardinalityOverflowValue}))
+		Expect(metrics("log")[1].Props).To(Equal(map[string]interface{}{"id": 2}))
+	})
+
+	It("Should report cardinality in the manifest and share limits with sub-emitters", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).WithCardinalityLimit(10)
+		metric := emitter.MetricWithProps("requests", COUNT, []string{"route"})
+		sub := emitter.NewSubEmitter().(*Emitter)
+
+		metric(ctx, map[string]interface{}{"route": "/a"})
+		sub.Count(ctx, "requests", map[string]interface{}{"route": "/b"}, 1)
+
+		manifest := emitter.GetManifest()
+		Expect(manifest).To(HaveLen(1))
+		Expect(manifest[0].Cardinality).To(Equal(2))
+		Expect(manifest[0].CardinalityLimit).To(Equal(10))
+	})
+})
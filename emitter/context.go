@@ -0,0 +1,33 @@
+package emitter
+
+import (
+	"context"
+	"maps"
+)
+
+type contextPropsKey struct{}
+
+// ContextWithProps returns a copy of ctx carrying props, which are merged into
+// every event emitted with the returned context. Props already on ctx are
+// kept, with props overriding them key by key, so nested calls stack.
+// Explicit props passed at the call site win over context props.
+//
+// This is intended for request-scoped dimensions such as tenant, route or
+// request_id that middleware can set once per request.
+func ContextWithProps(ctx context.Context, props map[string]interface{}) context.Context {
+	parent := PropsFromContext(ctx)
+	merged := make(map[string]interface{}, len(parent)+len(props))
+	maps.Copy(merged, parent)
+	maps.Copy(merged, props)
+	return context.WithValue(ctx, contextPropsKey{}, merged)
+}
+
+// PropsFromContext returns the props attached to ctx with ContextWithProps, or
+// nil if there are none. The returned map must not be modified.
+func PropsFromContext(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	props, _ := ctx.Value(contextPropsKey{}).(map[string]interface{})
+	return props
+}
@@ -0,0 +1,74 @@
+package emitter
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+	"github.com/pseudofunctor-ai/go-emitter/emitter/types/mocks"
+)
+
+var _ = Describe("Context props", func() {
+	var ctrl *gomock.Controller
+	var mockBackend *mocks.MockEmitterBackend
+	var emitter *Emitter
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockBackend = mocks.NewMockEmitterBackend(ctrl)
+		emitter = NewEmitter(mockBackend)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("Should return nil when no props were attached", func() {
+		Expect(PropsFromContext(context.Background())).To(BeNil())
+	})
+
+	It("Should merge context props into metrics", func() {
+		ctx := ContextWithProps(context.Background(), map[string]interface{}{"tenant": "acme"})
+
+		mockBackend.EXPECT().EmitInt(ctx, "requests", map[string]interface{}{"tenant": "acme", "route": "/users"}, int64(1), COUNT)
+		emitter.Count(ctx, "requests", map[string]interface{}{"route": "/users"}, 1)
+	})
+
+	It("Should merge context props into logs", func() {
+		ctx := ContextWithProps(context.Background(), map[string]interface{}{"request_id": "abc"})
+
+		mockBackend.EXPECT().EmitInt(ctx, "log", gomock.Any(), int64(1), COUNT).Do(func(_ context.Context, _ string, props map[string]interface{}, _ int64, _ MetricType) {
+			Expect(props).To(HaveKeyWithValue("request_id", "abc"))
+			Expect(props).To(HaveKeyWithValue("_message", "hello"))
+		})
+		emitter.InfoContext(ctx, "log", nil, "hello")
+	})
+
+	It("Should let explicit props win over context props", func() {
+		ctx := ContextWithProps(context.Background(), map[string]interface{}{"route": "/from-context"})
+
+		mockBackend.EXPECT().EmitInt(ctx, "requests", map[string]interface{}{"route": "/explicit"}, int64(1), COUNT)
+		emitter.Count(ctx, "requests", map[string]interface{}{"route": "/explicit"}, 1)
+	})
+
+	It("Should stack nested contexts with inner props winning", func() {
+		outer := ContextWithProps(context.Background(), map[string]interface{}{"tenant": "acme", "route": "/outer"})
+		inner := ContextWithProps(outer, map[string]interface{}{"route": "/inner"})
+
+		Expect(PropsFromContext(inner)).To(Equal(map[string]interface{}{"tenant": "acme", "route": "/inner"}))
+		Expect(PropsFromContext(outer)).To(Equal(map[string]interface{}{"tenant": "acme", "route": "/outer"}))
+	})
+
+	It("Should not modify the props passed in", func() {
+		ctx := ContextWithProps(context.Background(), map[string]interface{}{"tenant": "acme"})
+		props := map[string]interface{}{"route": "/users"}
+
+		mockBackend.EXPECT().EmitInt(ctx, "requests", gomock.Any(), int64(1), COUNT)
+		emitter.Count(ctx, "requests", props, 1)
+
+		Expect(props).To(Equal(map[string]interface{}{"route": "/users"}))
+	})
+})
@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"maps"
 	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"weak"
 
 	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
 )
@@ -31,29 +36,93 @@ type eventCallSiteProps struct {
 	package_ string
 }
 
+func (p eventCallSiteProps) details() t.CallSiteDetails {
+	return t.CallSiteDetails{
+		Filename: p.filename,
+		LineNo:   p.lineNo,
+		FuncName: p.funcName,
+		Package:  p.package_,
+	}
+}
+
 type eventMetadata struct {
   registeredDynamically bool
 	metricType            t.MetricType
 	propertyKeys          []string
+	// callSite is the call site given to WithStaticMetadata, if any
+	callSite              t.CallSiteDetails
+	info                  t.EventInfo
 }
 
+// Emitter fans events out to its backends. Registration, memoization and
+// WithBackend are safe to call from multiple goroutines; the remaining builder
+// methods are expected to be called during setup, before the emitter is shared.
 type Emitter struct {
-
+	// mu guards registeredEvents and children. The emit path never takes it.
+	mu                  sync.RWMutex
 	registeredEvents    map[string]*eventMetadata
-	memoTable           map[string]eventCallSiteProps
+	// children are the sub-emitters created from this emitter, so that
+	// Shutdown can reach their backends. See childRef.
+	children            []*childRef
+	// parent and ref are set on sub-emitters, for retain.
+	parent              *Emitter
+	ref                 *childRef
+	// memoTable is read on every emission, so it is a sync.Map to keep the
+	// lookup for already-memoized events lock-free.
+	memoTable           sync.Map // map[string]eventCallSiteProps
 	callback            func(context.Context, string, map[string]interface{})
 	hostname_provider   func() (string, error)
 	callsite_provider   func(eventName string) t.CallSiteDetails
-	backends            []t.EmitterBackend
+	// callSites is set by WithCallsiteProvider, asking for the call site of
+	// every event even without magic props, see eventCallSite.
+	callSites           bool
+	// backends is copy-on-write: WithBackend swaps in a new slice so the emit
+	// path can iterate a snapshot without locking.
+	backendsMu          sync.Mutex
+	backends            atomic.Pointer[[]*backendEntry]
+	// async is set by WithAsync; backends added afterwards get their own queue.
+	async               *AsyncOptions
+	errorHandler        func(context.Context, error)
+	// emitted counts the events emitted through this emitter, see Stats.
+	emitted             atomic.Uint64
 	magicHostname       bool
 	magicFilename       bool
 	magicLineNo         bool
 	magicFuncName       bool
 	magicPackage        bool
+	traceLogs           bool
+	traceMetrics        bool
+	// sampler is set by the WithSample* methods; nil keeps every event.
+	sampler             *sampler
+	// suppressor is set by WithLogSuppression and shared with sub-emitters.
+	suppressor          *suppressor
+	// levels holds the minimum log levels and is shared with sub-emitters.
+	levels              *logLevels
+	// cardinality is set by the With*CardinalityLimit methods and shared with
+	// sub-emitters.
+	cardinality         *cardinalityLimiter
+	// middleware is set by Use; head is the first middleware, which ends in
+	// send, or nil to call send directly.
+	middleware          []Middleware
+	head                t.EventBackend
+	// router is set by WithRoute and WithDefaultRoute; nil sends every event
+	// to every backend.
+	router              *router
+	// prefix, including its separator, is prepended to the names of events
+	// emitted and registered through this emitter, see NewSubEmitterWithOptions.
+	prefix              string
+	// defaultProps are merged into every event, below context and explicit props.
+	defaultProps        map[string]interface{}
+	// timers is set by WithTimerTracking and shared with sub-emitters.
+	timers              *timerTracker
+	// fatal is set by WithFatalHandler and shared with sub-emitters.
+	fatal               *fatalHandler
 }
 
 type TimingEmitter[T any] struct {
 	emitter t.DurationEmitter
+	// errorClass names the class of errors returned to TimeErr, see WithErrorClass
+	errorClass func(error) string
 }
 
 func NewTimingEmitter[T any](emitter t.DurationEmitter) TimingEmitter[T] {
@@ -66,9 +135,11 @@ func NewTimingEmitter[T any](emitter t.DurationEmitter) TimingEmitter[T] {
 func RuntimeCallsiteProvider(eventName string) t.CallSiteDetails {
 	skip := 2
 	_, thisFile, _, _ := runtime.Caller(0)
+	thisDir := filepath.Dir(thisFile)
 	pc, file, line, ok := runtime.Caller(skip)
 	// XXX: For some reason, when testing we skip 2, but elsewhere we seem to need to skip 3
-	for ok && file == thisFile {
+	// Skip every frame inside this package, other than its tests
+	for ok && filepath.Dir(file) == thisDir && !strings.HasSuffix(file, "_test.go") {
 		skip += 1
 		pc, file, line, ok = runtime.Caller(skip)
 	}
@@ -109,10 +180,8 @@ func StaticCallsiteProvider(staticData map[string]t.CallSiteDetails) func(string
 }
 
 func NewEmitter(backends ...t.EmitterBackend) *Emitter {
-	return &Emitter{
+	e := &Emitter{
 		registeredEvents:  make(map[string]*eventMetadata),
-		memoTable:         make(map[string]eventCallSiteProps),
-		backends:          backends,
 		magicHostname:     false,
 		magicFilename:     false,
 		magicLineNo:       false,
@@ -121,7 +190,23 @@ func NewEmitter(backends ...t.EmitterBackend) *Emitter {
 		callback:          nil,
 		hostname_provider: os.Hostname,
 		callsite_provider: RuntimeCallsiteProvider,
+		levels:            newLogLevels(),
+	}
+	entries := make([]*backendEntry, len(backends))
+	for i, backend := range backends {
+		entries[i] = e.newBackendEntry(AdaptBackend(backend))
+	}
+	e.backends.Store(&entries)
+	return e
+}
+
+// loadBackends returns the current snapshot of backends. The returned slice
+// must not be modified.
+func (e *Emitter) loadBackends() []*backendEntry {
+	if b := e.backends.Load(); b != nil {
+		return *b
 	}
+	return nil
 }
 
 // NewSubEmitter creates a new emitter that inherits all configuration from the parent
@@ -131,24 +216,54 @@ func NewEmitter(backends ...t.EmitterBackend) *Emitter {
 //
 // The returned emitter can be further configured using builder methods like
 // WithStaticMetadata, WithMagicHostname, etc.
+//
+// The parent keeps track of its sub-emitters so that Flush and Shutdown on the
+// parent also reach them. A sub-emitter that only uses the parent's backends
+// is tracked weakly, so sub-emitters created per request are garbage collected
+// once they are no longer used; one that adds backends or async queues of its
+// own is kept for as long as the parent.
 func (e *Emitter) NewSubEmitter() t.CombinedEmitter {
 	// Copy the backends slice to avoid sharing the underlying array
-	backendsCopy := make([]t.EmitterBackend, len(e.backends))
-	copy(backendsCopy, e.backends)
+	parentBackends := e.loadBackends()
+	backendsCopy := make([]*backendEntry, len(parentBackends))
+	copy(backendsCopy, parentBackends)
 
-	return &Emitter{
+	sub := &Emitter{
 		registeredEvents:  make(map[string]*eventMetadata),
-		memoTable:         make(map[string]eventCallSiteProps),
 		callback:          e.callback,
 		hostname_provider: e.hostname_provider,
 		callsite_provider: e.callsite_provider,
-		backends:          backendsCopy,
+		callSites:         e.callSites,
+		async:             e.async,
+		errorHandler:      e.errorHandler,
 		magicHostname:     e.magicHostname,
 		magicFilename:     e.magicFilename,
 		magicLineNo:       e.magicLineNo,
 		magicFuncName:     e.magicFuncName,
 		magicPackage:      e.magicPackage,
+		traceLogs:         e.traceLogs,
+		traceMetrics:      e.traceMetrics,
+		sampler:           e.sampler.clone(),
+		suppressor:        e.suppressor,
+		levels:            e.levels,
+		cardinality:       e.cardinality,
+		router:            e.router.clone(),
+		prefix:            e.prefix,
+		defaultProps:      e.defaultProps,
+		timers:            e.timers,
+		fatal:             e.fatal,
+		parent:            e,
 	}
+	sub.backends.Store(&backendsCopy)
+	if len(e.middleware) > 0 {
+		sub.Use(e.middleware...)
+	}
+
+	sub.ref = &childRef{weak: weak.Make(sub)}
+	e.mu.Lock()
+	e.children = appendChild(e.children, sub.ref)
+	e.mu.Unlock()
+	return sub
 }
 
 func (e *Emitter) WithCallback(callback func(context.Context, string, map[string]interface{})) *Emitter {
@@ -161,13 +276,31 @@ func (e *Emitter) WithHostnameProvider(hostname_provider func() (string, error))
 	return e
 }
 
+// WithCallsiteProvider replaces the runtime.Caller lookup of call sites, e.g.
+// with StaticCallsiteProvider. Events then carry their call site in
+// types.Event.CallSite, whether or not magic props are enabled.
 func (e *Emitter) WithCallsiteProvider(callsite_provider func(eventName string) t.CallSiteDetails) *Emitter {
 	e.callsite_provider = callsite_provider
+	e.callSites = true
 	return e
 }
 
 func (e *Emitter) WithBackend(backend t.EmitterBackend) *Emitter {
-	e.backends = append(e.backends, backend)
+	return e.WithEventBackend(AdaptBackend(backend))
+}
+
+// WithEventBackend adds a backend that receives whole events rather than the
+// EmitterBackend calls.
+func (e *Emitter) WithEventBackend(backend t.EventBackend) *Emitter {
+	e.backendsMu.Lock()
+	defer e.backendsMu.Unlock()
+
+	current := e.loadBackends()
+	next := make([]*backendEntry, len(current), len(current)+1)
+	copy(next, current)
+	next = append(next, e.newBackendEntry(backend))
+	e.backends.Store(&next)
+	e.retain()
 	return e
 }
 
@@ -211,8 +344,13 @@ func (e *Emitter) WithAllMagicProps() *Emitter {
 
 // WithStaticMetadata populates registeredEvents from statically generated metadata.
 // This is typically used with a generated CallSiteDetails map from the generator tool.
-// It extracts metric types and property keys from the static data.
+// It extracts metric types and property keys from the static data. The map is
+// keyed by the names passed to the emitter, so on a sub-emitter with a prefix
+// the events are registered under their full prefixed names.
 func (e *Emitter) WithStaticMetadata(staticData map[string]t.CallSiteDetails) t.CombinedEmitter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	for eventName, details := range staticData {
 		// Parse metric type from string
 		var metricType t.MetricType
@@ -237,33 +375,76 @@ func (e *Emitter) WithStaticMetadata(staticData map[string]t.CallSiteDetails) t.
 		}
 
 		// Register the event with metadata
-		e.registeredEvents[eventName] = &eventMetadata{
-      registeredDynamically: false,
-			metricType:   metricType,
-			propertyKeys: details.PropertyKeys,
+		e.registeredEvents[e.eventName(eventName)] = &eventMetadata{
+			registeredDynamically: false,
+			metricType:            metricType,
+			propertyKeys:          details.PropertyKeys,
+			callSite:              details,
+			info:                  details.Info(),
 		}
 	}
 
 	return e
 }
 
-func (e *Emitter) Metric(event string, metricType t.MetricType) t.MetricEmitterFn {
+// register records event as dynamically registered, panicking if it already is,
+// and describes it to the backends. Events that were only registered through
+// WithStaticMetadata are upgraded in place, with opts overriding their info.
+func (e *Emitter) register(event string, metricType t.MetricType, propKeys []string, opts []t.RegisterOption) {
+	e.mu.Lock()
 	if re, ok := e.registeredEvents[event]; ok {
-    if re.registeredDynamically {
-      panic(fmt.Sprintf("Event %s already registered", event))
-    }
-    re.registeredDynamically = true
+		if re.registeredDynamically {
+			e.mu.Unlock()
+			panic(fmt.Sprintf("Event %s already registered", event))
+		}
+		re.registeredDynamically = true
+		re.info = mergeInfo(re.info, eventInfo(opts))
 	} else {
-    e.registeredEvents[event] = &eventMetadata{
-      registeredDynamically: true,
-      metricType: metricType,
-      propertyKeys: nil,
-    }
-  }
+		e.registeredEvents[event] = &eventMetadata{
+			registeredDynamically: true,
+			metricType:            metricType,
+			propertyKeys:          propKeys,
+			info:                  eventInfo(opts),
+		}
+	}
+	e.mu.Unlock()
 
-	eCopy := *e
-	silentE := (&eCopy).WithoutMagicProps()
-	silentE.EmitInt(context.Background(), event, nil, 0, metricType)
+	e.describe(event, metricType)
+}
+
+// seed emits a zero value for a freshly registered event so that backends such
+// as Prometheus know about it before it first fires. Magic props are never
+// attached to seed emissions, but they do go through the middleware so that,
+// for example, renamed events are seeded under their new name.
+func (e *Emitter) seed(event string, props map[string]interface{}, metricType t.MetricType) {
+	ctx := context.Background()
+	if len(e.defaultProps) > 0 {
+		p := maps.Clone(e.defaultProps)
+		maps.Copy(p, props)
+		props = p
+	}
+	if props == nil {
+		props = make(map[string]interface{})
+	}
+	if e.callback != nil {
+		e.callback(ctx, event, props)
+	}
+	ev := &t.Event{
+		Kind:       t.MetricEvent,
+		Name:       event,
+		MetricType: metricType,
+		ValueKind:  t.IntValue,
+		Props:      props,
+		Timestamp:  time.Now(),
+	}
+	e.toBackends(ctx, ev)
+}
+
+// Metric registers a metric and returns a function that emits it. Options
+// such as WithDescription and WithUnit document the metric in the manifest.
+func (e *Emitter) Metric(event string, metricType t.MetricType, opts ...t.RegisterOption) t.MetricEmitterFn {
+	e.register(e.eventName(event), metricType, nil, opts)
+	e.seed(e.eventName(event), nil, metricType)
 
 	return func(ctx context.Context, props map[string]interface{}, value ...interface{}) {
     if len(value) == 0 {
@@ -291,23 +472,9 @@ func (e *Emitter) Metric(event string, metricType t.MetricType) t.MetricEmitterF
 	}
 }
 
-func (e *Emitter) Log(event string, logfn func(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{})) t.LogEmitterFn {
-	if re, ok := e.registeredEvents[event]; ok {
-    if re.registeredDynamically {
-      panic(fmt.Sprintf("Event %s already registered", event))
-    }
-    re.registeredDynamically = true
-	} else {
-    e.registeredEvents[event] = &eventMetadata{
-      registeredDynamically: true,
-      metricType: t.COUNT,
-      propertyKeys: nil,
-    }
-  }
-
-	eCopy := *e
-	silentE := (&eCopy).WithoutMagicProps()
-	silentE.EmitInt(context.Background(), event, nil, 0, t.COUNT)
+func (e *Emitter) Log(event string, logfn func(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}), opts ...t.RegisterOption) t.LogEmitterFn {
+	e.register(e.eventName(event), t.COUNT, nil, opts)
+	e.seed(e.eventName(event), nil, t.COUNT)
 
 	return func(ctx context.Context, props map[string]interface{}, format string, args ...interface{}) {
 		logfn(ctx, event, props, format, args...)
@@ -317,19 +484,8 @@ func (e *Emitter) Log(event string, logfn func(ctx context.Context, event string
 // MetricWithProps registers a metric with known property keys.
 // It emits a zero value with placeholder values for seeding backends like Prometheus.
 // The returned function validates that only expected property keys are used.
-func (e *Emitter) MetricWithProps(event string, metricType t.MetricType, propKeys []string) t.MetricEmitterFn {
-	if re, ok := e.registeredEvents[event]; ok {
-    if re.registeredDynamically {
-      panic(fmt.Sprintf("Event %s already registered", event))
-    }
-    re.registeredDynamically = true
-	} else {
-    e.registeredEvents[event] = &eventMetadata{
-      registeredDynamically: true,
-      metricType: metricType,
-      propertyKeys: propKeys,
-    }
-  }
+func (e *Emitter) MetricWithProps(event string, metricType t.MetricType, propKeys []string, opts ...t.RegisterOption) t.MetricEmitterFn {
+	e.register(e.eventName(event), metricType, propKeys, opts)
 
 	// Create seed props with placeholder values
 	seedProps := make(map[string]interface{}, len(propKeys))
@@ -338,9 +494,7 @@ func (e *Emitter) MetricWithProps(event string, metricType t.MetricType, propKey
 	}
 
 	// Emit zero with seed props for backend initialization
-	eCopy := *e
-	silentE := (&eCopy).WithoutMagicProps()
-	silentE.EmitInt(context.Background(), event, seedProps, 0, metricType)
+	e.seed(e.eventName(event), seedProps, metricType)
 
 	// Create a set for efficient lookup
 	propKeySet := make(map[string]struct{}, len(propKeys))
@@ -383,19 +537,8 @@ func (e *Emitter) MetricWithProps(event string, metricType t.MetricType, propKey
 // LogWithProps registers a log event with known property keys.
 // It emits a zero value with placeholder values for seeding backends.
 // The returned function validates that only expected property keys are used.
-func (e *Emitter) LogWithProps(event string, logfn func(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}), propKeys []string) t.LogEmitterFn {
-	if re, ok := e.registeredEvents[event]; ok {
-    if re.registeredDynamically {
-      panic(fmt.Sprintf("Event %s already registered", event))
-    }
-    re.registeredDynamically = true
-	} else {
-    e.registeredEvents[event] = &eventMetadata{
-      registeredDynamically: true,
-      metricType: t.COUNT,
-      propertyKeys: propKeys,
-    }
-  }
+func (e *Emitter) LogWithProps(event string, logfn func(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}), propKeys []string, opts ...t.RegisterOption) t.LogEmitterFn {
+	e.register(e.eventName(event), t.COUNT, propKeys, opts)
 
 	// Create seed props with placeholder values
 	seedProps := make(map[string]interface{}, len(propKeys))
@@ -404,9 +547,7 @@ func (e *Emitter) LogWithProps(event string, logfn func(ctx context.Context, eve
 	}
 
 	// Emit zero with seed props for backend initialization
-	eCopy := *e
-	silentE := (&eCopy).WithoutMagicProps()
-	silentE.EmitInt(context.Background(), event, seedProps, 0, t.COUNT)
+	e.seed(e.eventName(event), seedProps, t.COUNT)
 
 	// Create a set for efficient lookup
 	propKeySet := make(map[string]struct{}, len(propKeys))
@@ -442,36 +583,33 @@ func (e *Emitter) LogFnCallsite(fn t.LogEmitterFn) t.LogEmitterFn {
 }
 
 func (e *Emitter) addDynamicPropsToEvent(ctx context.Context, eventName string, props map[string]interface{}) map[string]interface{} {
-	if props == nil {
-		props = make(map[string]interface{}, 5)
-	}
-
-	// Check if we need to add any magic props or invoke callback
-	if e.callback == nil && !e.magicFilename && !e.magicLineNo && !e.magicFuncName && !e.magicHostname && !e.magicPackage {
+	ctxProps := PropsFromContext(ctx)
+
+	// Check if we need to add any magic props or invoke callback. The caller's
+	// map is handed to the backends as-is, so it must not be written to here,
+	// and nil props stay nil so that emitting without props doesn't allocate.
+	needsCallSite := e.callback != nil || e.magicProps()
+	if len(ctxProps) == 0 && len(e.defaultProps) == 0 && !needsCallSite {
+		if _, ok := props["__includes_magic_props"]; ok {
+			props = maps.Clone(props)
+			delete(props, "__includes_magic_props")
+		}
 		return props
 	}
 
-	// Clone props to avoid modifying the original
-	p := maps.Clone(props)
-
-	// Get or compute the event call site props
-	var eventProps *eventCallSiteProps
-	if v, ok := e.memoTable[eventName]; ok {
-		eventProps = &v
-	} else {
-		hostname, _ := e.hostname_provider()
-		callsite := e.callsite_provider(eventName)
-		v := eventCallSiteProps{
-			hostname: hostname,
-			filename: callsite.Filename,
-			lineNo:   callsite.LineNo,
-			funcName: callsite.FuncName,
-			package_: callsite.Package,
-		}
-		e.memoTable[eventName] = v
-		eventProps = &v
+	// Copy props to avoid modifying the original. Default props go in first,
+	// then context props, so that explicit props win.
+	p := make(map[string]interface{}, len(e.defaultProps)+len(ctxProps)+len(props)+5)
+	maps.Copy(p, e.defaultProps)
+	maps.Copy(p, ctxProps)
+	maps.Copy(p, props)
+	delete(p, "__includes_magic_props")
+	if !needsCallSite {
+		return p
 	}
 
+	eventProps := e.callSiteProps(eventName)
+
 	// Add magic props based on flags
 	if e.magicHostname && eventProps.hostname != "" {
 		p["hostname"] = eventProps.hostname
@@ -496,28 +634,161 @@ func (e *Emitter) addDynamicPropsToEvent(ctx context.Context, eventName string,
 	return p
 }
 
+// magicProps reports whether any magic prop is enabled.
+func (e *Emitter) magicProps() bool {
+	return e.magicHostname || e.magicFilename || e.magicLineNo || e.magicFuncName || e.magicPackage
+}
+
+// eventCallSite returns the call site to put on an event. Looking it up costs
+// a runtime.Caller and a memoTable entry per event name, so it is only done
+// when the emitter already needs call sites: for magic props, the callback or
+// an explicit callsite provider. Otherwise the event only gets a call site
+// that was memoized for other reasons, e.g. by package levels or StartTimer.
+func (e *Emitter) eventCallSite(eventName string) t.CallSiteDetails {
+	if e.callSites || e.callback != nil || e.magicProps() {
+		return e.callSiteProps(eventName).details()
+	}
+	if v, ok := e.memoTable.Load(eventName); ok {
+		return v.(eventCallSiteProps).details()
+	}
+	return t.CallSiteDetails{}
+}
+
+// callSiteProps returns the memoized call site details for eventName, computing
+// and storing them on first use. Concurrent first uses may both compute the
+// details; only one result is kept. eventName is the full event name, but the
+// callsite provider is asked for the name as written at the call site, without
+// the prefix, which is how the generator keys its static data.
+func (e *Emitter) callSiteProps(eventName string) eventCallSiteProps {
+	if v, ok := e.memoTable.Load(eventName); ok {
+		return v.(eventCallSiteProps)
+	}
+
+	hostname, _ := e.hostname_provider()
+	callsite := e.callsite_provider(strings.TrimPrefix(eventName, e.prefix))
+	v, _ := e.memoTable.LoadOrStore(eventName, eventCallSiteProps{
+		hostname: hostname,
+		filename: callsite.Filename,
+		lineNo:   callsite.LineNo,
+		funcName: callsite.FuncName,
+		package_: callsite.Package,
+	})
+	return v.(eventCallSiteProps)
+}
+
 // Implement EmitterBackend in case we want to stack emitters
 func (e *Emitter) EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType t.MetricType) {
-	p := e.addDynamicPropsToEvent(ctx, event, props)
-	delete(p, "__includes_magic_props")
-	for _, backend := range e.backends {
-		backend.EmitFloat(ctx, event, p, value, metricType)
-	}
+	ev := eventFromProps(e.eventName(event), props, metricType)
+	ev.ValueKind = t.FloatValue
+	ev.Float = value
+	e.emit(ctx, ev)
 }
 
 func (e *Emitter) EmitInt(ctx context.Context, event string, props map[string]interface{}, value int64, metricType t.MetricType) {
-	p := e.addDynamicPropsToEvent(ctx, event, props)
-	delete(p, "__includes_magic_props")
-	for _, backend := range e.backends {
-		backend.EmitInt(ctx, event, p, value, metricType)
-	}
+	ev := eventFromProps(e.eventName(event), props, metricType)
+	ev.ValueKind = t.IntValue
+	ev.Int = value
+	e.emit(ctx, ev)
 }
 
 func (e *Emitter) EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType t.MetricType) {
-	p := e.addDynamicPropsToEvent(ctx, event, props)
-	delete(p, "__includes_magic_props")
-	for _, backend := range e.backends {
-		backend.EmitDuration(ctx, event, p, value, metricType)
+	ev := eventFromProps(e.eventName(event), props, metricType)
+	ev.ValueKind = t.DurationValue
+	ev.Duration = value
+	e.emit(ctx, ev)
+}
+
+// Emit satisfies types.EventBackend, so that a parent emitter hands whole
+// events to a stacked emitter. The event is copied before it is re-emitted.
+func (e *Emitter) Emit(ctx context.Context, event *t.Event) {
+	ev := *event
+	ev.Name = e.eventName(event.Name)
+	e.emit(ctx, &ev)
+}
+
+// eventFromProps builds an event from the EmitterBackend calling convention.
+// Logs and sampled events from older callers carry their details as reserved
+// props, which are moved into the event's fields.
+func eventFromProps(event string, props map[string]interface{}, metricType t.MetricType) *t.Event {
+	ev := &t.Event{Kind: t.MetricEvent, Name: event, MetricType: metricType, Props: props}
+
+	msg, isLog := props["_message"].(string)
+	levelName, _ := props["_logLevel"].(string)
+	_, sampled := props[SampledProp]
+	if !isLog && !sampled {
+		return ev
+	}
+
+	ev.Props = maps.Clone(props)
+	if isLog {
+		ev.Kind = t.LogEvent
+		ev.Message = msg
+		ev.Level, _ = t.ParseLevel(levelName)
+		delete(ev.Props, "_message")
+		delete(ev.Props, "_logLevel")
+	}
+	if sampled {
+		ev.SampleRate, _ = props[SampleRateProp].(float64)
+		delete(ev.Props, SampleRateProp)
+		delete(ev.Props, SampledProp)
+	}
+	return ev
+}
+
+// emit applies the minimum log level, sampling and log suppression to an
+// event and dispatches it if it is kept.
+func (e *Emitter) emit(ctx context.Context, ev *t.Event) {
+	if ev.Kind == t.LogEvent && !e.enabled(ev.Name, ev.Level) {
+		return
+	}
+	if !ev.Sampled() {
+		rate, keep := e.sample(ev)
+		if !keep {
+			return
+		}
+		ev.SampleRate = rate
+	}
+	if ev.Kind == t.LogEvent && e.suppressor != nil && !e.suppressor.allow(e, ctx, ev) {
+		return
+	}
+	e.dispatch(ctx, ev)
+}
+
+// dispatch computes the event's dynamic props, once, and hands the event to
+// the middleware and every backend.
+func (e *Emitter) dispatch(ctx context.Context, ev *t.Event) {
+	ev.Props = e.addDynamicPropsToEvent(ctx, ev.Name, ev.Props)
+	if e.traceMetrics || (e.traceLogs && ev.Kind == t.LogEvent) {
+		ev.Props = withTraceProps(ctx, ev.Props)
+	}
+	if ev.Kind == t.MetricEvent && e.cardinality != nil {
+		e.limitCardinality(ctx, ev)
+	}
+	ev.CallSite = e.eventCallSite(ev.Name)
+	ev.Timestamp = time.Now()
+
+	e.emitted.Add(1)
+	e.toBackends(ctx, ev)
+}
+
+// toBackends passes the event through the emitter's middleware to send.
+func (e *Emitter) toBackends(ctx context.Context, ev *t.Event) {
+	if e.head != nil {
+		e.head.Emit(ctx, ev)
+		return
+	}
+	e.send(ctx, ev)
+}
+
+// send hands the event to every backend it is routed to.
+func (e *Emitter) send(ctx context.Context, ev *t.Event) {
+	names, routed := e.route(ev)
+	for _, entry := range e.loadBackends() {
+		if routed && !slices.Contains(names, entry.route) {
+			continue
+		}
+		entry.backend.Emit(ctx, ev)
+		entry.stats.emitted.Add(1)
 	}
 }
 
@@ -604,71 +875,92 @@ func (e *Emitter) Tracef(event string, props map[string]interface{}, format stri
 	e.TracefContext(context.Background(), event, props, format, args...)
 }
 
+// emitLog emits a log event under its full name. The level is checked before
+// anything is allocated so that discarded logs stay cheap.
+func (e *Emitter) emitLog(ctx context.Context, event string, props map[string]interface{}, level t.Level, msg string) {
+	if !e.enabled(event, level) {
+		return
+	}
+	e.emit(ctx, &t.Event{
+		Kind:       t.LogEvent,
+		Name:       event,
+		MetricType: t.COUNT,
+		ValueKind:  t.IntValue,
+		Int:        1,
+		Level:      level,
+		Message:    msg,
+		Props:      props,
+	})
+}
+
 // Implement SimpleContextLogger
 func (e *Emitter) InfoContext(ctx context.Context, event string, props map[string]interface{}, msg string) {
-	updatedProps := e.addDynamicPropsToEvent(ctx, event, props)
-	updatedProps["_message"] = msg
-	updatedProps["_logLevel"] = "INFO"
-	e.EmitInt(ctx, event, updatedProps, 1, t.COUNT)
+	e.emitLog(ctx, e.eventName(event), props, t.LevelInfo, msg)
 }
 
 func (e *Emitter) WarnContext(ctx context.Context, event string, props map[string]interface{}, msg string) {
-	updatedProps := e.addDynamicPropsToEvent(ctx, event, props)
-	updatedProps["_message"] = msg
-	updatedProps["_logLevel"] = "WARN"
-	e.EmitInt(ctx, event, updatedProps, 1, t.COUNT)
+	e.emitLog(ctx, e.eventName(event), props, t.LevelWarn, msg)
 }
 
 func (e *Emitter) ErrorContext(ctx context.Context, event string, props map[string]interface{}, msg string) {
-	updatedProps := e.addDynamicPropsToEvent(ctx, event, props)
-	updatedProps["_message"] = msg
-	updatedProps["_logLevel"] = "ERROR"
-	e.EmitInt(ctx, event, updatedProps, 1, t.COUNT)
+	e.emitLog(ctx, e.eventName(event), props, t.LevelError, msg)
 }
 
 func (e *Emitter) FatalContext(ctx context.Context, event string, props map[string]interface{}, msg string) {
-	updatedProps := e.addDynamicPropsToEvent(ctx, event, props)
-	updatedProps["_message"] = msg
-	updatedProps["_logLevel"] = "FATAL"
-	e.EmitInt(ctx, event, updatedProps, 1, t.COUNT)
+	name := e.eventName(event)
+	e.emitLog(ctx, name, props, t.LevelFatal, msg)
+	e.handleFatal(ctx, name, msg)
 }
 
 func (e *Emitter) DebugContext(ctx context.Context, event string, props map[string]interface{}, msg string) {
-	updatedProps := e.addDynamicPropsToEvent(ctx, event, props)
-	updatedProps["_message"] = msg
-	updatedProps["_logLevel"] = "DEBUG"
-	e.EmitInt(ctx, event, updatedProps, 1, t.COUNT)
+	e.emitLog(ctx, e.eventName(event), props, t.LevelDebug, msg)
 }
 
 func (e *Emitter) TraceContext(ctx context.Context, event string, props map[string]interface{}, msg string) {
-	updatedProps := e.addDynamicPropsToEvent(ctx, event, props)
-	updatedProps["_message"] = msg
-	updatedProps["_logLevel"] = "TRACE"
-	e.EmitInt(ctx, event, updatedProps, 1, t.COUNT)
+	e.emitLog(ctx, e.eventName(event), props, t.LevelTrace, msg)
 }
 
 // Implement FormatContextLogger
 func (e *Emitter) InfofContext(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}) {
+	if !e.Enabled(event, t.LevelInfo) {
+		return
+	}
 	e.InfoContext(ctx, event, props, fmt.Sprintf(format, args...))
 }
 
 func (e *Emitter) WarnfContext(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}) {
+	if !e.Enabled(event, t.LevelWarn) {
+		return
+	}
 	e.WarnContext(ctx, event, props, fmt.Sprintf(format, args...))
 }
 
 func (e *Emitter) ErrorfContext(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}) {
+	if !e.Enabled(event, t.LevelError) {
+		return
+	}
 	e.ErrorContext(ctx, event, props, fmt.Sprintf(format, args...))
 }
 
 func (e *Emitter) FatalfContext(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}) {
+	// The fatal handler acts even when the log is disabled
+	if !e.Enabled(event, t.LevelFatal) && e.fatal == nil {
+		return
+	}
 	e.FatalContext(ctx, event, props, fmt.Sprintf(format, args...))
 }
 
 func (e *Emitter) DebugfContext(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}) {
+	if !e.Enabled(event, t.LevelDebug) {
+		return
+	}
 	e.DebugContext(ctx, event, props, fmt.Sprintf(format, args...))
 }
 
 func (e *Emitter) TracefContext(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}) {
+	if !e.Enabled(event, t.LevelTrace) {
+		return
+	}
 	e.TraceContext(ctx, event, props, fmt.Sprintf(format, args...))
 }
 
@@ -721,16 +1013,39 @@ func (c *CompatAdapter) TraceContext(ctx context.Context, msg string, args ...in
 // This can be used to generate documentation or publish as a JSON manifest.
 // Property keys use placeholder values ("*") to indicate dynamic dimensions.
 func (e *Emitter) GetManifest() []t.MetricManifestEntry {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	manifest := make([]t.MetricManifestEntry, 0, len(e.registeredEvents))
 
 	for eventName, metadata := range e.registeredEvents {
+		cardinality, limit := e.cardinalityOf(eventName)
+		callSite := e.manifestCallSite(eventName, metadata)
 		manifest = append(manifest, t.MetricManifestEntry{
-			Name:         eventName,
-			MetricType:   metadata.metricType,
-			TypeString:   metadata.metricType.String(),
-			PropertyKeys: metadata.propertyKeys,
+			Name:             eventName,
+			MetricType:       metadata.metricType,
+			TypeString:       metadata.metricType.String(),
+			PropertyKeys:     metadata.propertyKeys,
+			EventInfo:        metadata.info,
+			Cardinality:      cardinality,
+			CardinalityLimit: limit,
+			Filename:         callSite.Filename,
+			LineNo:           callSite.LineNo,
+			FuncName:         callSite.FuncName,
+			Package:          callSite.Package,
 		})
 	}
 
 	return manifest
 }
+
+// manifestCallSite returns the call site memoized for event, falling back to
+// its static metadata. Unlike callSiteProps it never computes the call site.
+func (e *Emitter) manifestCallSite(event string, metadata *eventMetadata) t.CallSiteDetails {
+	if v, ok := e.memoTable.Load(event); ok {
+		if details := v.(eventCallSiteProps).details(); details.Filename != "" {
+			return details
+		}
+	}
+	return metadata.callSite
+}
@@ -2,8 +2,10 @@ package emitter
 
 import (
 	"context"
+	"fmt"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -915,7 +917,10 @@ var _ = Describe("Emitter", func() {
 
 			// Sub-emitter should have fresh maps
 			Expect(subEmitter.registeredEvents).To(BeEmpty())
-			Expect(subEmitter.memoTable).To(BeEmpty())
+			subEmitter.memoTable.Range(func(key, _ any) bool {
+				Fail("unexpected memoized event " + key.(string))
+				return false
+			})
 
 			// Parent should still have its registered event
 			Expect(emitter.registeredEvents).To(HaveLen(1))
@@ -966,18 +971,18 @@ var _ = Describe("Emitter", func() {
 			sub := parent.NewSubEmitter().(*Emitter)
 
 			// Both should have same backend initially
-			Expect(sub.backends).To(HaveLen(1))
+			Expect(sub.loadBackends()).To(HaveLen(1))
 
 			// Add backend to sub - should not affect parent
 			sub.WithBackend(mockBackend2)
-			Expect(sub.backends).To(HaveLen(2))
-			Expect(parent.backends).To(HaveLen(1))
+			Expect(sub.loadBackends()).To(HaveLen(2))
+			Expect(parent.loadBackends()).To(HaveLen(1))
 
 			// Add backend to parent - should not affect sub
 			mockBackend3 := mocks.NewMockEmitterBackend(ctrl)
 			parent.WithBackend(mockBackend3)
-			Expect(parent.backends).To(HaveLen(2))
-			Expect(sub.backends).To(HaveLen(2))
+			Expect(parent.loadBackends()).To(HaveLen(2))
+			Expect(sub.loadBackends()).To(HaveLen(2))
 		})
 
 		It("Should allow different metadata via WithStaticMetadata", func() {
@@ -1050,15 +1055,54 @@ var _ = Describe("Emitter", func() {
 
 			// Emit from parent - should memoize
 			parent.Count(context.Background(), "shared.event", nil, 1)
-			Expect(parent.memoTable).To(HaveKey("shared.event"))
+			parentMemo, ok := parent.memoTable.Load("shared.event")
+			Expect(ok).To(BeTrue())
 
 			// Sub should have its own memoization
 			sub.Count(context.Background(), "shared.event", nil, 1)
-			Expect(sub.memoTable).To(HaveKey("shared.event"))
+			subMemo, ok := sub.memoTable.Load("shared.event")
+			Expect(ok).To(BeTrue())
 
 			// Verify they memoized different values (different hostnames)
-			Expect(parent.memoTable["shared.event"].hostname).To(Equal("parent-host"))
-			Expect(sub.memoTable["shared.event"].hostname).To(Equal("sub-host"))
+			Expect(parentMemo.(eventCallSiteProps).hostname).To(Equal("parent-host"))
+			Expect(subMemo.(eventCallSiteProps).hostname).To(Equal("sub-host"))
+		})
+	})
+
+	Describe("Concurrency", func() {
+		It("Should allow emitting, registering and adding backends from many goroutines", func() {
+			mockBackend.EXPECT().EmitInt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockBackend.EXPECT().EmitFloat(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			extraBackend := mocks.NewMockEmitterBackend(ctrl)
+			extraBackend.EXPECT().EmitInt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			extraBackend.EXPECT().EmitFloat(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+			emitter := NewEmitter(mockBackend).WithAllMagicProps().WithHostnameProvider(func() (string, error) { return "localhost", nil })
+			sharedProps := map[string]interface{}{"key": "value"}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					metricFn := emitter.Metric(fmt.Sprintf("registered.%d", i), COUNT)
+					for j := 0; j < 50; j++ {
+						emitter.Count(context.Background(), fmt.Sprintf("event.%d", j%5), sharedProps, 1)
+						emitter.Gauge(context.Background(), "gauge", sharedProps, float64(j))
+						emitter.InfoContext(context.Background(), "log", sharedProps, "hello")
+						metricFn(context.Background(), sharedProps)
+					}
+					emitter.WithBackend(extraBackend)
+					emitter.GetManifest()
+				}(i)
+			}
+			wg.Wait()
+
+			Expect(emitter.loadBackends()).To(HaveLen(9))
+			Expect(emitter.GetManifest()).To(HaveLen(8))
+			Expect(sharedProps).To(Equal(map[string]interface{}{"key": "value"}))
 		})
 	})
 })
@@ -0,0 +1,168 @@
+package emitter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// eventRecorder is an EventBackend that keeps a copy of every event.
+type eventRecorder struct {
+	mu      sync.Mutex
+	events  []Event
+	flushed bool
+}
+
+func (r *eventRecorder) Emit(ctx context.Context, event *Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ev := *event
+	// Attrs are only valid for the duration of the call
+	ev.Attrs = append([]Attr(nil), event.Attrs...)
+	r.events = append(r.events, ev)
+}
+
+func (r *eventRecorder) Flush(ctx context.Context) error {
+	r.flushed = true
+	return nil
+}
+
+func (r *eventRecorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+var _ = Describe("Events", func() {
+	var ctx context.Context
+	var recorder *eventRecorder
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &eventRecorder{}
+	})
+
+	It("Should deliver logs with the level and message as fields", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).WithCallsiteProvider(StaticCallsiteProvider(map[string]CallSiteDetails{
+			"db.down": {Filename: "db.go", LineNo: 12, Package: "github.com/acme/db"},
+		}))
+
+		before := time.Now()
+		emitter.WarnContext(ctx, "db.down", map[string]interface{}{"host": "a"}, "connection refused")
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(1))
+		ev := events[0]
+		Expect(ev.Kind).To(Equal(LogEvent))
+		Expect(ev.Name).To(Equal("db.down"))
+		Expect(ev.MetricType).To(Equal(COUNT))
+		Expect(ev.ValueKind).To(Equal(IntValue))
+		Expect(ev.Int).To(Equal(int64(1)))
+		Expect(ev.Level).To(Equal(LevelWarn))
+		Expect(ev.Message).To(Equal("connection refused"))
+		Expect(ev.Props).To(Equal(map[string]interface{}{"host": "a"}))
+		Expect(ev.CallSite.Filename).To(Equal("db.go"))
+		Expect(ev.CallSite.Package).To(Equal("github.com/acme/db"))
+		Expect(ev.Timestamp).To(BeTemporally(">=", before))
+		Expect(ev.Sampled()).To(BeFalse())
+	})
+
+	It("Should deliver metrics with their value", func() {
+		emitter := NewEmitter().WithEventBackend(recorder)
+
+		emitter.Count(ctx, "count", nil, 3)
+		emitter.Gauge(ctx, "gauge", nil, 1.5)
+		emitter.EmitDuration(ctx, "timer", nil, time.Second, TIMER)
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(3))
+		Expect(events[0].Kind).To(Equal(MetricEvent))
+		Expect(events[0].Int).To(Equal(int64(3)))
+		Expect(events[1].ValueKind).To(Equal(FloatValue))
+		Expect(events[1].Float).To(Equal(1.5))
+		Expect(events[2].ValueKind).To(Equal(DurationValue))
+		Expect(events[2].Float64()).To(Equal(1.0))
+	})
+
+	It("Should compute dynamic props once per log", func() {
+		calls := 0
+		backend := newGatedBackend()
+		backend.open()
+		emitter := NewEmitter(backend).WithCallback(func(context.Context, string, map[string]interface{}) { calls++ })
+
+		emitter.Info("event", nil, "msg")
+		Expect(calls).To(Equal(1))
+	})
+
+	It("Should not look up call sites nobody asked for", func() {
+		lookups := 0
+		emitter := NewEmitter().WithEventBackend(recorder).
+			WithHostnameProvider(func() (string, error) { lookups++; return "host", nil })
+		emitter.callsite_provider = func(string) CallSiteDetails { lookups++; return CallSiteDetails{} }
+
+		ctx = ContextWithProps(ctx, map[string]interface{}{"request_id": "r1"})
+		emitter.Count(ctx, "count", nil, 1)
+		emitter.Info("log", nil, "msg")
+		emitter.StartTimer(ctx, "timer", nil).Stop()
+
+		Expect(lookups).To(BeZero())
+		Expect(recorder.Events()).To(HaveLen(3))
+		Expect(recorder.Events()[0].CallSite).To(BeZero())
+		Expect(recorder.Events()[0].Props).To(HaveKeyWithValue("request_id", "r1"))
+	})
+
+	It("Should pass logs to EmitterBackends as reserved props", func() {
+		backend := newGatedBackend()
+		backend.open()
+		emitter := NewEmitter(backend).WithSampleRate("sampled", 0.5).WithSamplingSeed(1)
+
+		emitter.Error("event", map[string]interface{}{"key": "value"}, "msg")
+		Expect(backend.Props("event")).To(Equal(map[string]interface{}{"key": "value", "_message": "msg", "_logLevel": "ERROR"}))
+
+		for i := 0; i < 10; i++ {
+			emitter.Count(ctx, "sampled", nil, 1)
+		}
+		Expect(backend.Props("sampled")).To(Equal(map[string]interface{}{SampleRateProp: 0.5, SampledProp: true}))
+	})
+
+	It("Should turn reserved props from EmitterBackend callers into event fields", func() {
+		emitter := NewEmitter().WithEventBackend(recorder)
+
+		emitter.EmitInt(ctx, "event", map[string]interface{}{"_message": "msg", "_logLevel": "DEBUG", SampleRateProp: 0.25, SampledProp: true, "key": "value"}, 1, COUNT)
+
+		ev := recorder.Events()[0]
+		Expect(ev.Kind).To(Equal(LogEvent))
+		Expect(ev.Level).To(Equal(LevelDebug))
+		Expect(ev.Message).To(Equal("msg"))
+		Expect(ev.SampleRate).To(Equal(0.25))
+		Expect(ev.Props).To(Equal(map[string]interface{}{"key": "value"}))
+	})
+
+	It("Should hand whole events to stacked emitters", func() {
+		inner := NewEmitter().WithEventBackend(recorder)
+		outer := NewEmitter(inner)
+
+		outer.Info("event", nil, "msg")
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Kind).To(Equal(LogEvent))
+		Expect(events[0].Message).To(Equal("msg"))
+	})
+
+	It("Should name, flush and queue event backends like any other backend", func() {
+		emitter := NewEmitter().WithAsync(AsyncOptions{}).WithEventBackend(recorder)
+
+		emitter.Count(ctx, "event", nil, 1)
+		Expect(emitter.Flush(ctx)).To(Succeed())
+
+		Expect(recorder.Events()).To(HaveLen(1))
+		Expect(recorder.flushed).To(BeTrue())
+		Expect(emitter.Stats().Backends[0].Name).To(Equal("*emitter.eventRecorder"))
+	})
+})
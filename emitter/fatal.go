@@ -0,0 +1,119 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FatalAction is what the emitter does after a FATAL log.
+type FatalAction int
+
+const (
+	// FatalContinue only emits the log; the caller carries on. This is the default.
+	FatalContinue FatalAction = iota
+	// FatalExit shuts the emitter down and exits the process.
+	FatalExit
+	// FatalPanic shuts the emitter down and panics with a *FatalError.
+	FatalPanic
+)
+
+func (a FatalAction) String() string {
+	switch a {
+	case FatalContinue:
+		return "CONTINUE"
+	case FatalExit:
+		return "EXIT"
+	case FatalPanic:
+		return "PANIC"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// FatalOptions configures what happens after a FATAL log. See Emitter.WithFatalHandler.
+type FatalOptions struct {
+	Action FatalAction
+	// ExitCode is passed to Exit by FatalExit. Defaults to 1.
+	ExitCode int
+	// Timeout bounds flushing and closing the backends. Defaults to 5 seconds.
+	Timeout time.Duration
+	// Exit replaces os.Exit, so that tests can intercept the exit. If it
+	// returns, so does the Fatal call.
+	Exit func(code int)
+}
+
+const defaultFatalTimeout = 5 * time.Second
+
+// FatalError is the panic value of FatalPanic.
+type FatalError struct {
+	Event   string
+	Message string
+	// Err is the error returned by the shutdown, if any
+	Err error
+}
+
+func (f *FatalError) Error() string {
+	return fmt.Sprintf("fatal: %s: %s", f.Event, f.Message)
+}
+
+func (f *FatalError) Unwrap() error {
+	return f.Err
+}
+
+// fatalHandler is shared with sub-emitters, so that a FATAL log on any of them
+// shuts down the emitter the handler was set on, with all its sub-emitters.
+type fatalHandler struct {
+	opts FatalOptions
+	root *Emitter
+
+	once sync.Once
+	err  error
+}
+
+// WithFatalHandler makes Fatal, Fatalf, FatalContext, FatalfContext and
+// FatalAttrs act on opts.Action after emitting the log, even if FATAL logs are
+// disabled for the event. Before exiting or panicking, the emitter is shut down
+// as by Shutdown, with opts.Timeout as the deadline, so the FATAL log and
+// everything before it reaches the backends. Sub-emitters created afterwards
+// share the handler and shut this emitter down.
+func (e *Emitter) WithFatalHandler(opts FatalOptions) *Emitter {
+	if opts.ExitCode == 0 {
+		opts.ExitCode = 1
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultFatalTimeout
+	}
+	if opts.Exit == nil {
+		opts.Exit = os.Exit
+	}
+	e.fatal = &fatalHandler{opts: opts, root: e}
+	return e
+}
+
+// handleFatal acts on a FATAL log for event, the full event name.
+func (e *Emitter) handleFatal(ctx context.Context, event string, msg string) {
+	h := e.fatal
+	if h == nil || h.opts.Action == FatalContinue {
+		return
+	}
+
+	// Concurrent FATAL logs shut down once and all wait for it
+	h.once.Do(func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), h.opts.Timeout)
+		defer cancel()
+		h.err = h.root.Shutdown(ctx)
+	})
+
+	switch h.opts.Action {
+	case FatalExit:
+		if h.err != nil {
+			fmt.Fprintf(os.Stderr, "emitter: shutdown before exit: %v\n", h.err)
+		}
+		h.opts.Exit(h.opts.ExitCode)
+	case FatalPanic:
+		panic(&FatalError{Event: event, Message: msg, Err: h.err})
+	}
+}
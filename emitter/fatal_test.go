@@ -0,0 +1,88 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+var _ = Describe("Fatal handler", func() {
+	var ctx context.Context
+	var log *callLog
+	var backend *lifecycleBackend
+	var emitter *Emitter
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		log = &callLog{}
+		backend = &lifecycleBackend{name: "a", log: log}
+		emitter = NewEmitter(backend)
+	})
+
+	It("Should keep running by default", func() {
+		emitter.Fatal("db.down", nil, "connection refused")
+		Expect(log.Calls()).To(Equal([]string{"a.emit.db.down"}))
+	})
+
+	It("Should shut down before exiting", func() {
+		code := -1
+		emitter.WithFatalHandler(FatalOptions{
+			Action:   FatalExit,
+			ExitCode: 3,
+			Exit: func(c int) {
+				log.add("exit")
+				code = c
+			},
+		})
+
+		emitter.FatalContext(ctx, "db.down", nil, "connection refused")
+		Expect(code).To(Equal(3))
+		Expect(log.Calls()).To(Equal([]string{"a.emit.db.down", "a.flush", "a.close", "exit"}))
+	})
+
+	It("Should exit with code 1 unless told otherwise", func() {
+		code := -1
+		emitter.WithFatalHandler(FatalOptions{Action: FatalExit, Exit: func(c int) { code = c }})
+
+		emitter.FatalAttrs(ctx, "db.down", "connection refused", String("host", "a"))
+		Expect(code).To(Equal(1))
+	})
+
+	It("Should panic with a FatalError carrying the shutdown error", func() {
+		backend.closeErr = errors.New("close failed")
+		emitter.WithFatalHandler(FatalOptions{Action: FatalPanic})
+
+		var fatal *FatalError
+		Expect(func() {
+			defer func() {
+				fatal = recover().(*FatalError)
+			}()
+			emitter.Fatalf("db.down", nil, "connection to %s refused", "db-1")
+		}).NotTo(Panic())
+		Expect(fatal.Event).To(Equal("db.down"))
+		Expect(fatal.Message).To(Equal("connection to db-1 refused"))
+		Expect(fatal).To(MatchError(backend.closeErr))
+		Expect(log.Calls()).To(Equal([]string{"a.emit.db.down", "a.flush", "a.close"}))
+	})
+
+	It("Should act on disabled FATAL logs and logs from sub-emitters", func() {
+		exits := 0
+		emitter.WithFatalHandler(FatalOptions{Action: FatalExit, Timeout: time.Second, Exit: func(int) { exits++ }})
+		emitter.SetLevel(LevelFatal + 1)
+		sub := emitter.NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "billing"})
+
+		sub.FatalfContext(ctx, "invoice.failed", nil, "giving up")
+		Expect(exits).To(Equal(1))
+		Expect(log.Calls()).To(Equal([]string{"a.flush", "a.close"}))
+
+		// The emitter is only shut down once
+		emitter.Fatal("db.down", nil, "connection refused")
+		Expect(exits).To(Equal(2))
+		Expect(log.Calls()).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,101 @@
+package emitter
+
+import (
+	"slices"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// WithDescription describes what a registered event means, for the manifest
+// and for backends such as OTel that attach descriptions to instruments.
+func WithDescription(description string) t.RegisterOption {
+	return func(info *t.EventInfo) {
+		info.Description = description
+	}
+}
+
+// WithUnit sets the unit of a registered metric, preferably as a UCUM code
+// such as "ms", "By" or "{request}".
+func WithUnit(unit string) t.RegisterOption {
+	return func(info *t.EventInfo) {
+		info.Unit = unit
+	}
+}
+
+// WithOwner records the team or person responsible for a registered event.
+func WithOwner(owner string) t.RegisterOption {
+	return func(info *t.EventInfo) {
+		info.Owner = owner
+	}
+}
+
+// WithRunbookURL links a registered event to the runbook for its alerts.
+func WithRunbookURL(url string) t.RegisterOption {
+	return func(info *t.EventInfo) {
+		info.RunbookURL = url
+	}
+}
+
+// WithTags adds free-form tags to a registered event, e.g. "slo".
+func WithTags(tags ...string) t.RegisterOption {
+	return func(info *t.EventInfo) {
+		info.Tags = append(info.Tags, tags...)
+	}
+}
+
+// WithBuckets suggests explicit bucket boundaries, in the metric's unit, for
+// a histogram or timer to backends that support them, such as OTel.
+func WithBuckets(boundaries ...float64) t.RegisterOption {
+	return func(info *t.EventInfo) {
+		info.Buckets = slices.Clone(boundaries)
+	}
+}
+
+func eventInfo(opts []t.RegisterOption) t.EventInfo {
+	var info t.EventInfo
+	for _, opt := range opts {
+		opt(&info)
+	}
+	return info
+}
+
+// mergeInfo returns base with the fields set in over replacing its own.
+func mergeInfo(base, over t.EventInfo) t.EventInfo {
+	if over.Description != "" {
+		base.Description = over.Description
+	}
+	if over.Unit != "" {
+		base.Unit = over.Unit
+	}
+	if over.Owner != "" {
+		base.Owner = over.Owner
+	}
+	if over.RunbookURL != "" {
+		base.RunbookURL = over.RunbookURL
+	}
+	if len(over.Tags) > 0 {
+		base.Tags = slices.Clone(over.Tags)
+	}
+	if len(over.Buckets) > 0 {
+		base.Buckets = slices.Clone(over.Buckets)
+	}
+	return base
+}
+
+// describe hands the info of a freshly registered event to the backends that
+// implement types.Describer.
+func (e *Emitter) describe(event string, metricType t.MetricType) {
+	e.mu.RLock()
+	metadata, ok := e.registeredEvents[event]
+	var info t.EventInfo
+	if ok {
+		info = metadata.info
+	}
+	e.mu.RUnlock()
+
+	for _, entry := range e.loadBackends() {
+		if d, ok := unwrapBackend(entry.backend).(t.Describer); ok {
+			d.Describe(event, metricType, info)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package emitter
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// describingBackend records the events described to it.
+type describingBackend struct {
+	discardBackend
+	described map[string]EventInfo
+}
+
+func (d *describingBackend) Describe(event string, metricType MetricType, info EventInfo) {
+	if d.described == nil {
+		d.described = make(map[string]EventInfo)
+	}
+	d.described[event] = info
+}
+
+var _ = Describe("Registration options", func() {
+	manifestEntry := func(emitter *Emitter, name string) MetricManifestEntry {
+		for _, entry := range emitter.GetManifest() {
+			if entry.Name == name {
+				return entry
+			}
+		}
+		Fail("no manifest entry for " + name)
+		return MetricManifestEntry{}
+	}
+
+	It("Should record options in the manifest", func() {
+		emitter := NewEmitter()
+		emitter.Metric("latency", TIMER,
+			WithDescription("Request latency"),
+			WithUnit("ms"),
+			WithOwner("platform"),
+			WithRunbookURL("https://runbooks.example.com/latency"),
+			WithTags("slo"), WithTags("http"),
+		)
+		emitter.LogWithProps("audit", emitter.InfofContext, []string{"user"}, WithOwner("security"))
+		RegisterCounter[struct{}](emitter, "typed", WithUnit("{request}"))
+
+		Expect(manifestEntry(emitter, "latency").EventInfo).To(Equal(EventInfo{
+			Description: "Request latency",
+			Unit:        "ms",
+			Owner:       "platform",
+			RunbookURL:  "https://runbooks.example.com/latency",
+			Tags:        []string{"slo", "http"},
+		}))
+		Expect(manifestEntry(emitter, "audit").Owner).To(Equal("security"))
+		Expect(manifestEntry(emitter, "typed").Unit).To(Equal("{request}"))
+	})
+
+	It("Should let options override static metadata", func() {
+		emitter := NewEmitter()
+		emitter.WithStaticMetadata(map[string]CallSiteDetails{
+			"latency": {MetricType: "TIMER", Description: "Static", Unit: "s", Owner: "platform"},
+		})
+		Expect(manifestEntry(emitter, "latency").Description).To(Equal("Static"))
+
+		emitter.Metric("latency", TIMER, WithDescription("Dynamic"))
+
+		info := manifestEntry(emitter, "latency").EventInfo
+		Expect(info).To(Equal(EventInfo{Description: "Dynamic", Unit: "s", Owner: "platform"}))
+	})
+
+	It("Should describe registered events to backends", func() {
+		backend := &describingBackend{}
+		emitter := NewEmitter().WithEventBackend(Chain(backend)).WithAsync(AsyncOptions{})
+		defer emitter.Shutdown(context.Background())
+
+		emitter.Metric("latency", TIMER, WithUnit("ms"))
+
+		Expect(backend.described).To(Equal(map[string]EventInfo{"latency": {Unit: "ms"}}))
+	})
+})
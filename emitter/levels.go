@@ -0,0 +1,123 @@
+package emitter
+
+import (
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// logLevels holds the minimum log levels. It is shared between an emitter and
+// its sub-emitters, so a level changed on the root applies everywhere. Reads
+// are lock-free; overrides are copied on write.
+type logLevels struct {
+	min      atomic.Int32
+	mu       sync.Mutex
+	events   atomic.Pointer[map[string]t.Level]
+	packages atomic.Pointer[map[string]t.Level]
+}
+
+func newLogLevels() *logLevels {
+	return &logLevels{}
+}
+
+func (l *logLevels) set(overrides *atomic.Pointer[map[string]t.Level], key string, level t.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	m := make(map[string]t.Level)
+	if current := overrides.Load(); current != nil {
+		maps.Copy(m, *current)
+	}
+	m[key] = level
+	overrides.Store(&m)
+}
+
+func (l *logLevels) clear(overrides *atomic.Pointer[map[string]t.Level], key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	current := overrides.Load()
+	if current == nil {
+		return
+	}
+	m := maps.Clone(*current)
+	delete(m, key)
+	if len(m) == 0 {
+		overrides.Store(nil)
+		return
+	}
+	overrides.Store(&m)
+}
+
+// packageLevel returns the override for pkg or its closest parent package.
+func packageLevel(overrides map[string]t.Level, pkg string) (t.Level, bool) {
+	for {
+		if level, ok := overrides[pkg]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(pkg, '/')
+		if i < 0 {
+			return t.LevelTrace, false
+		}
+		pkg = pkg[:i]
+	}
+}
+
+// SetLevel sets the minimum level of logs the emitter dispatches. Logs below it
+// are discarded before any props are computed. It is safe to call at runtime.
+// The default is types.LevelTrace, which dispatches every log.
+func (e *Emitter) SetLevel(level t.Level) {
+	e.levels.min.Store(int32(level))
+}
+
+// Level returns the minimum level set with SetLevel.
+func (e *Emitter) Level() t.Level {
+	return t.Level(e.levels.min.Load())
+}
+
+// SetEventLevel overrides the minimum level for a single event, e.g. to turn on
+// DEBUG logs for one event in production. Event overrides take precedence over
+// package overrides and the global level. On a sub-emitter, event is prefixed
+// like the events it emits.
+func (e *Emitter) SetEventLevel(event string, level t.Level) {
+	e.levels.set(&e.levels.events, e.eventName(event), level)
+}
+
+// ClearEventLevel removes an override set with SetEventLevel.
+func (e *Emitter) ClearEventLevel(event string) {
+	e.levels.clear(&e.levels.events, e.eventName(event))
+}
+
+// SetPackageLevel overrides the minimum level for logs emitted from a package,
+// as reported by the callsite provider, and its subpackages. The most specific
+// package override wins.
+func (e *Emitter) SetPackageLevel(pkg string, level t.Level) {
+	e.levels.set(&e.levels.packages, pkg, level)
+}
+
+// ClearPackageLevel removes an override set with SetPackageLevel.
+func (e *Emitter) ClearPackageLevel(pkg string) {
+	e.levels.clear(&e.levels.packages, pkg)
+}
+
+// Enabled reports whether a log for event at level would be dispatched. Use it
+// to skip building expensive props for logs that would be discarded.
+func (e *Emitter) Enabled(event string, level t.Level) bool {
+	return e.enabled(e.eventName(event), level)
+}
+
+// enabled is Enabled for an event's full name.
+func (e *Emitter) enabled(event string, level t.Level) bool {
+	if events := e.levels.events.Load(); events != nil {
+		if min, ok := (*events)[event]; ok {
+			return level >= min
+		}
+	}
+	if packages := e.levels.packages.Load(); packages != nil {
+		if min, ok := packageLevel(*packages, e.callSiteProps(event).package_); ok {
+			return level >= min
+		}
+	}
+	return level >= e.Level()
+}
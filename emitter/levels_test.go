@@ -0,0 +1,153 @@
+package emitter
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// countingStringer counts how often it is formatted.
+type countingStringer struct{ calls int }
+
+func (c *countingStringer) String() string {
+	c.calls++
+	return "value"
+}
+
+var _ = Describe("Log levels", func() {
+	var ctx context.Context
+	var backend *gatedBackend
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		backend = newGatedBackend()
+		backend.open()
+	})
+
+	It("Should dispatch every level by default", func() {
+		emitter := NewEmitter(backend)
+		Expect(emitter.Level()).To(Equal(LevelTrace))
+
+		emitter.Trace("trace", nil, "msg")
+		emitter.Debug("debug", nil, "msg")
+		Expect(backend.Events()).To(Equal([]string{"trace", "debug"}))
+	})
+
+	It("Should skip logs below the minimum level before doing any work", func() {
+		callbacks := 0
+		emitter := NewEmitter(backend).WithCallback(func(context.Context, string, map[string]interface{}) { callbacks++ })
+		emitter.SetLevel(LevelInfo)
+
+		arg := &countingStringer{}
+		emitter.DebugfContext(ctx, "debug", nil, "got %s", arg)
+		emitter.Trace("trace", nil, "msg")
+		Expect(backend.Events()).To(BeEmpty())
+		Expect(callbacks).To(BeZero())
+		Expect(arg.calls).To(BeZero())
+
+		emitter.InfofContext(ctx, "info", nil, "got %s", arg)
+		emitter.Error("error", nil, "msg")
+		Expect(backend.Events()).To(Equal([]string{"info", "error"}))
+		Expect(arg.calls).To(Equal(1))
+	})
+
+	It("Should never filter metrics", func() {
+		emitter := NewEmitter(backend)
+		emitter.SetLevel(LevelFatal)
+
+		emitter.Count(ctx, "metric", nil, 1)
+		Expect(backend.Events()).To(Equal([]string{"metric"}))
+	})
+
+	It("Should let event overrides take precedence and be cleared at runtime", func() {
+		emitter := NewEmitter(backend)
+		emitter.SetLevel(LevelWarn)
+		emitter.SetEventLevel("noisy", LevelDebug)
+		emitter.SetEventLevel("quiet", LevelFatal)
+
+		emitter.Debug("noisy", nil, "msg")
+		emitter.Debug("other", nil, "msg")
+		emitter.Error("quiet", nil, "msg")
+		Expect(backend.Events()).To(Equal([]string{"noisy"}))
+
+		emitter.ClearEventLevel("noisy")
+		emitter.Debug("noisy", nil, "msg")
+		Expect(backend.Events()).To(Equal([]string{"noisy"}))
+	})
+
+	It("Should apply the most specific package override", func() {
+		emitter := NewEmitter(backend).WithCallsiteProvider(StaticCallsiteProvider(map[string]CallSiteDetails{
+			"db.query":  {Package: "github.com/acme/svc/db"},
+			"api.call":  {Package: "github.com/acme/svc/api"},
+			"elsewhere": {Package: "github.com/acme/other"},
+		}))
+		emitter.SetLevel(LevelWarn)
+		emitter.SetPackageLevel("github.com/acme/svc", LevelDebug)
+		emitter.SetPackageLevel("github.com/acme/svc/db", LevelError)
+
+		emitter.Debug("db.query", nil, "msg")
+		emitter.Debug("api.call", nil, "msg")
+		emitter.Debug("elsewhere", nil, "msg")
+		Expect(backend.Events()).To(Equal([]string{"api.call"}))
+
+		// Event overrides win over package overrides
+		emitter.SetEventLevel("db.query", LevelDebug)
+		emitter.Debug("db.query", nil, "msg")
+		Expect(backend.Events()).To(Equal([]string{"api.call", "db.query"}))
+
+		emitter.ClearPackageLevel("github.com/acme/svc")
+		emitter.Debug("api.call", nil, "msg")
+		Expect(backend.Events()).To(Equal([]string{"api.call", "db.query"}))
+	})
+
+	It("Should resolve the package from the runtime call site", func() {
+		emitter := NewEmitter(backend)
+		emitter.SetLevel(LevelError)
+		emitter.SetPackageLevel("github.com/pseudofunctor-ai/go-emitter/emitter", LevelDebug)
+
+		emitter.Debug("debug", nil, "msg")
+		Expect(backend.Events()).To(Equal([]string{"debug"}))
+	})
+
+	It("Should share levels with sub-emitters", func() {
+		emitter := NewEmitter(backend)
+		sub := emitter.NewSubEmitter().(*Emitter)
+
+		emitter.SetLevel(LevelError)
+		sub.Info("info", nil, "msg")
+		Expect(backend.Events()).To(BeEmpty())
+	})
+
+	It("Should allow levels to change while logging", func() {
+		emitter := NewEmitter(backend)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					emitter.Debug("event", nil, "msg")
+				}
+			}()
+		}
+		for j := 0; j < 100; j++ {
+			emitter.SetEventLevel("event", Level(j%3))
+			emitter.SetLevel(Level(j % 4))
+		}
+		wg.Wait()
+	})
+
+	It("Should parse level names", func() {
+		level, err := ParseLevel("debug")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(level).To(Equal(LevelDebug))
+
+		_, err = ParseLevel("verbose")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,164 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// lifecycleTargets is the deduplicated set of asynchronous queues and leaf
+// backends reachable from an emitter.
+type lifecycleTargets struct {
+	emitters map[*Emitter]struct{}
+	seen     map[interface{}]struct{}
+	queues   []*asyncBackend
+	backends []interface{}
+}
+
+// addBackend records backend unless it has been seen before. Backends whose
+// dynamic type is not comparable cannot be deduplicated and are always added.
+func (l *lifecycleTargets) addBackend(backend interface{}) bool {
+	if reflect.TypeOf(backend).Comparable() {
+		if _, ok := l.seen[backend]; ok {
+			return false
+		}
+		l.seen[backend] = struct{}{}
+	}
+	l.backends = append(l.backends, backend)
+	return true
+}
+
+func (l *lifecycleTargets) walk(e *Emitter) {
+	if _, ok := l.emitters[e]; ok {
+		return
+	}
+	l.emitters[e] = struct{}{}
+
+	for _, entry := range e.loadBackends() {
+		if a, ok := entry.backend.(*asyncBackend); ok {
+			if _, ok := l.seen[a]; ok {
+				continue
+			}
+			l.seen[a] = struct{}{}
+			l.queues = append(l.queues, a)
+		}
+		backend := unwrapBackend(entry.backend)
+		// Stacked emitters are walked rather than treated as leaves, so that
+		// their backends are flushed and closed exactly once.
+		if nested, ok := backend.(*Emitter); ok {
+			l.walk(nested)
+			continue
+		}
+		l.addBackend(backend)
+	}
+
+	for _, child := range e.subEmitters() {
+		l.walk(child)
+	}
+}
+
+// lifecycleTargets walks the emitter's backends, its sub-emitters and any
+// stacked emitters used as backends.
+func (e *Emitter) lifecycleTargets() *lifecycleTargets {
+	l := &lifecycleTargets{
+		emitters: make(map[*Emitter]struct{}),
+		seen:     make(map[interface{}]struct{}),
+	}
+	l.walk(e)
+	return l
+}
+
+func flushQueues(ctx context.Context, queues []*asyncBackend) error {
+	var errs []error
+	for _, a := range queues {
+		if err := a.flush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (e *Emitter) flush(ctx context.Context, targets *lifecycleTargets) error {
+	// Suppression summaries go out first so they are flushed with everything else
+	for em := range targets.emitters {
+		if em.suppressor != nil {
+			em.suppressor.flush()
+		}
+	}
+
+	// Drain first so that the drop report itself does not compete with a full queue
+	if err := flushQueues(ctx, targets.queues); err != nil {
+		return err
+	}
+	e.reportDrops(ctx, targets.queues)
+	if err := flushQueues(ctx, targets.queues); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, backend := range targets.backends {
+		if f, ok := backend.(t.Flusher); ok {
+			if err := f.Flush(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start calls Start on every backend that implements types.Starter, including
+// the backends of sub-emitters and stacked emitters. Each backend is started once.
+func (e *Emitter) Start(ctx context.Context) error {
+	var errs []error
+	for _, backend := range e.lifecycleTargets().backends {
+		if s, ok := backend.(t.Starter); ok {
+			if err := s.Start(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Flush emits pending log suppression summaries, blocks until every
+// asynchronously queued event has been handed to its backend, reports any
+// dropped events, and then calls Flush on every backend that implements
+// types.Flusher. Sub-emitters and stacked emitters are included.
+func (e *Emitter) Flush(ctx context.Context) error {
+	return e.flush(ctx, e.lifecycleTargets())
+}
+
+// Shutdown flushes the emitter as Flush does, stops the asynchronous queue
+// workers, and then calls Close on every backend that implements types.Closer.
+// Sub-emitters and stacked emitters are included and each backend is closed
+// once, so Shutdown should be called on the root emitter only. Events emitted
+// after Shutdown are dropped by asynchronous queues.
+func (e *Emitter) Shutdown(ctx context.Context) error {
+	targets := e.lifecycleTargets()
+
+	var errs []error
+	if err := e.flush(ctx, targets); err != nil {
+		errs = append(errs, err)
+	}
+	for _, a := range targets.queues {
+		if err := a.close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, backend := range targets.backends {
+		if c, ok := backend.(t.Closer); ok {
+			if err := c.Close(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close satisfies types.Closer so that an Emitter can itself be used as a
+// backend. It is equivalent to Shutdown.
+func (e *Emitter) Close(ctx context.Context) error {
+	return e.Shutdown(ctx)
+}
@@ -0,0 +1,155 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// callLog records lifecycle calls across several backends in order.
+type callLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (c *callLog) add(call string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+}
+
+func (c *callLog) Calls() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.calls...)
+}
+
+type lifecycleBackend struct {
+	name     string
+	log      *callLog
+	closeErr error
+}
+
+func (l *lifecycleBackend) EmitInt(ctx context.Context, event string, props map[string]interface{}, value int64, metricType MetricType) {
+	l.log.add(l.name + ".emit." + event)
+}
+
+func (l *lifecycleBackend) EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType MetricType) {
+}
+
+func (l *lifecycleBackend) EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType MetricType) {
+}
+
+func (l *lifecycleBackend) Start(ctx context.Context) error {
+	l.log.add(l.name + ".start")
+	return nil
+}
+
+func (l *lifecycleBackend) Flush(ctx context.Context) error {
+	l.log.add(l.name + ".flush")
+	return nil
+}
+
+func (l *lifecycleBackend) Close(ctx context.Context) error {
+	l.log.add(l.name + ".close")
+	return l.closeErr
+}
+
+var _ = Describe("Lifecycle", func() {
+	var ctx context.Context
+	var log *callLog
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		log = &callLog{}
+	})
+
+	It("Should flush every backend before closing any", func() {
+		a := &lifecycleBackend{name: "a", log: log}
+		b := &lifecycleBackend{name: "b", log: log}
+		emitter := NewEmitter(a, b)
+
+		Expect(emitter.Shutdown(ctx)).To(Succeed())
+		Expect(log.Calls()).To(Equal([]string{"a.flush", "b.flush", "a.close", "b.close"}))
+	})
+
+	It("Should start backends that implement Starter", func() {
+		a := &lifecycleBackend{name: "a", log: log}
+		emitter := NewEmitter(a, newGatedBackend())
+
+		Expect(emitter.Start(ctx)).To(Succeed())
+		Expect(log.Calls()).To(Equal([]string{"a.start"}))
+	})
+
+	It("Should reach sub-emitters and stacked emitters exactly once", func() {
+		shared := &lifecycleBackend{name: "shared", log: log}
+		subOnly := &lifecycleBackend{name: "sub", log: log}
+		nestedOnly := &lifecycleBackend{name: "nested", log: log}
+
+		nested := NewEmitter(nestedOnly, shared)
+		root := NewEmitter(shared, nested)
+		sub := root.NewSubEmitter().(*Emitter)
+		sub.WithBackend(subOnly)
+
+		Expect(root.Flush(ctx)).To(Succeed())
+		Expect(log.Calls()).To(ConsistOf("shared.flush", "nested.flush", "sub.flush"))
+	})
+
+	It("Should let sub-emitters without backends of their own be collected", func() {
+		root := NewEmitter(&lifecycleBackend{name: "shared", log: log})
+		func() {
+			for n := range 100 {
+				sub := root.NewSubEmitterWithOptions(SubEmitterOptions{Props: map[string]interface{}{"request_id": n}})
+				sub.Count(ctx, "request", nil, 1)
+			}
+			// A nested sub-emitter with a backend keeps its parents alive
+			owner := root.NewSubEmitter().(*Emitter).NewSubEmitter().(*Emitter)
+			owner.WithBackend(&lifecycleBackend{name: "owned", log: log})
+		}()
+
+		Eventually(func() int {
+			runtime.GC()
+			return len(root.subEmitters())
+		}).Should(Equal(1))
+
+		// References to collected sub-emitters are pruned as new ones are added
+		for range 10 {
+			func() {
+				for range 100 {
+					root.NewSubEmitter()
+				}
+			}()
+			runtime.GC()
+		}
+		Expect(len(root.children)).To(BeNumerically("<", 300))
+
+		Expect(root.Shutdown(ctx)).To(Succeed())
+		Expect(log.Calls()).To(ContainElements("shared.close", "owned.close"))
+	})
+
+	It("Should drain asynchronous queues before flushing backends", func() {
+		a := &lifecycleBackend{name: "a", log: log}
+		emitter := NewEmitter(a).WithAsync(AsyncOptions{})
+
+		emitter.Count(ctx, "event", nil, 1)
+		Expect(emitter.Shutdown(ctx)).To(Succeed())
+		Expect(log.Calls()).To(Equal([]string{"a.emit.event", "a.flush", "a.close"}))
+	})
+
+	It("Should keep closing backends after an error and report it", func() {
+		closeErr := errors.New("close failed")
+		a := &lifecycleBackend{name: "a", log: log, closeErr: closeErr}
+		b := &lifecycleBackend{name: "b", log: log}
+		emitter := NewEmitter(a, b)
+
+		Expect(emitter.Shutdown(ctx)).To(MatchError(closeErr))
+		Expect(log.Calls()).To(ContainElement("b.close"))
+	})
+})
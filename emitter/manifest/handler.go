@@ -0,0 +1,99 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// mediaTypes maps the media types accepted in an Accept header to formats.
+var mediaTypes = map[string]Format{
+	"application/json":   JSON,
+	"application/yaml":   YAML,
+	"application/x-yaml": YAML,
+	"text/yaml":          YAML,
+	"text/x-yaml":        YAML,
+	"text/markdown":      Markdown,
+	"text/x-markdown":    Markdown,
+	"text/plain":         Markdown,
+	"text/*":             Markdown,
+	"application/*":      JSON,
+	"*/*":                JSON,
+}
+
+// Handler serves the live manifest of src. The format is taken from the
+// "format" query parameter ("json", "yaml" or "markdown") or, without one,
+// negotiated from the Accept header, defaulting to JSON. Requests for any
+// other format get 406 Not Acceptable.
+func Handler(src Source) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		format, ok := negotiate(r)
+		if !ok {
+			http.Error(w, fmt.Sprintf("supported formats: %v", Formats), http.StatusNotAcceptable)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := Write(&buf, format, src.GetManifest()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Add("Vary", "Accept")
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.Write(buf.Bytes())
+	})
+}
+
+// negotiate picks the format for r. Media ranges in the Accept header are
+// tried by descending q value, then in the order they were given.
+func negotiate(r *http.Request) (Format, bool) {
+	if q := r.URL.Query().Get("format"); q != "" {
+		for _, f := range Formats {
+			if string(f) == q {
+				return f, true
+			}
+		}
+		if q == "md" {
+			return Markdown, true
+		}
+		return "", false
+	}
+
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return JSON, true
+	}
+	best, bestQ := Format(""), 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		format, ok := mediaTypes[mediaType]
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+	return best, best != ""
+}
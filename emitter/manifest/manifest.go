@@ -0,0 +1,237 @@
+// Package manifest renders an emitter's manifest, the list of events it has
+// registered, as JSON, YAML or a Markdown table, and serves it over HTTP.
+//
+// Entries are always written sorted by name, so the output can be committed
+// and diffed:
+//
+//	http.Handle("/metrics/manifest", manifest.Handler(em))
+//
+//	manifest.Write(os.Stdout, manifest.Markdown, em.GetManifest())
+package manifest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// Source provides the live manifest. *emitter.Emitter is a Source.
+type Source interface {
+	GetManifest() []t.MetricManifestEntry
+}
+
+// SourceFunc adapts a function to Source, e.g. to serve
+// Emitter.GetManifestWithChildren:
+//
+//	manifest.Handler(manifest.SourceFunc(em.GetManifestWithChildren))
+type SourceFunc func() []t.MetricManifestEntry
+
+func (f SourceFunc) GetManifest() []t.MetricManifestEntry {
+	return f()
+}
+
+// Format is an output format for Write.
+type Format string
+
+const (
+	JSON     Format = "json"
+	YAML     Format = "yaml"
+	Markdown Format = "markdown"
+)
+
+// Formats lists the supported formats, in order of preference.
+var Formats = []Format{JSON, YAML, Markdown}
+
+// ContentType returns the media type a format is served as.
+func (f Format) ContentType() string {
+	switch f {
+	case YAML:
+		return "application/yaml"
+	case Markdown:
+		return "text/markdown; charset=utf-8"
+	default:
+		return "application/json"
+	}
+}
+
+// Sorted returns a copy of entries sorted by name, then metric type, with
+// their property keys sorted too.
+func Sorted(entries []t.MetricManifestEntry) []t.MetricManifestEntry {
+	sorted := make([]t.MetricManifestEntry, len(entries))
+	for i, entry := range entries {
+		entry.PropertyKeys = slices.Clone(entry.PropertyKeys)
+		slices.Sort(entry.PropertyKeys)
+		sorted[i] = entry
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].MetricType < sorted[j].MetricType
+	})
+	return sorted
+}
+
+// Write renders entries, sorted, in the given format.
+func Write(w io.Writer, format Format, entries []t.MetricManifestEntry) error {
+	switch format {
+	case JSON:
+		return WriteJSON(w, entries)
+	case YAML:
+		return WriteYAML(w, entries)
+	case Markdown:
+		return WriteMarkdown(w, entries)
+	default:
+		return fmt.Errorf("unknown manifest format %q", format)
+	}
+}
+
+// WriteJSON writes entries, sorted, as an indented JSON array.
+func WriteJSON(w io.Writer, entries []t.MetricManifestEntry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	sorted := Sorted(entries)
+	if sorted == nil {
+		sorted = []t.MetricManifestEntry{}
+	}
+	return enc.Encode(sorted)
+}
+
+// WriteYAML writes entries, sorted, as a YAML sequence using the same keys as
+// the JSON output.
+func WriteYAML(w io.Writer, entries []t.MetricManifestEntry) error {
+	bw := bufio.NewWriter(w)
+	sorted := Sorted(entries)
+	if len(sorted) == 0 {
+		bw.WriteString("[]\n")
+	}
+	for _, entry := range sorted {
+		fmt.Fprintf(bw, "- name: %s\n", strconv.Quote(entry.Name))
+		fmt.Fprintf(bw, "  metric_type: %d\n", entry.MetricType)
+		fmt.Fprintf(bw, "  type_string: %s\n", strconv.Quote(entry.TypeString))
+		if len(entry.PropertyKeys) > 0 {
+			bw.WriteString("  property_keys:\n")
+			for _, key := range entry.PropertyKeys {
+				fmt.Fprintf(bw, "    - %s\n", strconv.Quote(key))
+			}
+		}
+		if entry.Description != "" {
+			fmt.Fprintf(bw, "  description: %s\n", strconv.Quote(entry.Description))
+		}
+		if entry.Unit != "" {
+			fmt.Fprintf(bw, "  unit: %s\n", strconv.Quote(entry.Unit))
+		}
+		if entry.Owner != "" {
+			fmt.Fprintf(bw, "  owner: %s\n", strconv.Quote(entry.Owner))
+		}
+		if entry.RunbookURL != "" {
+			fmt.Fprintf(bw, "  runbook_url: %s\n", strconv.Quote(entry.RunbookURL))
+		}
+		if len(entry.Tags) > 0 {
+			bw.WriteString("  tags:\n")
+			for _, tag := range entry.Tags {
+				fmt.Fprintf(bw, "    - %s\n", strconv.Quote(tag))
+			}
+		}
+		if len(entry.Buckets) > 0 {
+			bounds := make([]string, len(entry.Buckets))
+			for i, bound := range entry.Buckets {
+				bounds[i] = strconv.FormatFloat(bound, 'g', -1, 64)
+			}
+			fmt.Fprintf(bw, "  buckets: [%s]\n", strings.Join(bounds, ", "))
+		}
+		if entry.Cardinality != 0 {
+			fmt.Fprintf(bw, "  cardinality: %d\n", entry.Cardinality)
+		}
+		if entry.CardinalityLimit != 0 {
+			fmt.Fprintf(bw, "  cardinality_limit: %d\n", entry.CardinalityLimit)
+		}
+		if entry.Filename != "" {
+			fmt.Fprintf(bw, "  filename: %s\n", strconv.Quote(entry.Filename))
+		}
+		if entry.LineNo != 0 {
+			fmt.Fprintf(bw, "  line_no: %d\n", entry.LineNo)
+		}
+		if entry.FuncName != "" {
+			fmt.Fprintf(bw, "  func_name: %s\n", strconv.Quote(entry.FuncName))
+		}
+		if entry.Package != "" {
+			fmt.Fprintf(bw, "  package: %s\n", strconv.Quote(entry.Package))
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteMarkdown writes entries, sorted, as a Markdown table.
+func WriteMarkdown(w io.Writer, entries []t.MetricManifestEntry) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("| Name | Type | Unit | Description | Owner | Tags | Properties | Cardinality | Call site |\n")
+	bw.WriteString("| --- | --- | --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, entry := range Sorted(entries) {
+		keys := make([]string, len(entry.PropertyKeys))
+		for i, key := range entry.PropertyKeys {
+			keys[i] = "`" + key + "`"
+		}
+		description := entry.Description
+		if entry.RunbookURL != "" {
+			if description != "" {
+				description += " "
+			}
+			description += "([runbook](" + entry.RunbookURL + "))"
+		}
+		fmt.Fprintf(bw, "| `%s` | %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			markdownCell(entry.Name),
+			entry.TypeString,
+			markdownCell(entry.Unit),
+			markdownCell(description),
+			markdownCell(entry.Owner),
+			markdownCell(strings.Join(entry.Tags, ", ")),
+			markdownCell(strings.Join(keys, ", ")),
+			cardinality(entry),
+			markdownCell(callSite(entry)),
+		)
+	}
+	return bw.Flush()
+}
+
+func cardinality(entry t.MetricManifestEntry) string {
+	if entry.CardinalityLimit == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d / %d", entry.Cardinality, entry.CardinalityLimit)
+}
+
+// callSite formats the call site as file:line (package.func).
+func callSite(entry t.MetricManifestEntry) string {
+	var s string
+	if entry.Filename != "" {
+		s = entry.Filename
+		if entry.LineNo != 0 {
+			s += ":" + strconv.Itoa(entry.LineNo)
+		}
+	}
+	fn := entry.FuncName
+	if entry.Package != "" && fn != "" && !strings.Contains(fn, ".") {
+		fn = entry.Package + "." + fn
+	}
+	if fn != "" {
+		if s != "" {
+			s += " "
+		}
+		s += "(" + fn + ")"
+	}
+	return s
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+func markdownCell(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,157 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pseudofunctor-ai/go-emitter/emitter"
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+var _ = Describe("Manifest", func() {
+	var em *emitter.Emitter
+
+	BeforeEach(func() {
+		em = emitter.NewEmitter()
+		em.MetricWithProps("requests", t.COUNT, []string{"status", "route"})
+		em.Metric("latency", t.TIMER,
+			emitter.WithDescription("Request latency"),
+			emitter.WithUnit("ms"),
+			emitter.WithOwner("platform"),
+			emitter.WithRunbookURL("https://runbooks.example.com/latency"),
+			emitter.WithTags("slo", "http"),
+		)
+		em.WithStaticMetadata(map[string]t.CallSiteDetails{
+			"cache.hits": {Filename: "cache.go", LineNo: 42, FuncName: "Get", Package: "cache", MetricType: "COUNT"},
+		})
+	})
+
+	render := func(format Format) string {
+		var buf bytes.Buffer
+		Expect(Write(&buf, format, em.GetManifest())).To(Succeed())
+		return buf.String()
+	}
+
+	It("Should sort entries and property keys", func() {
+		var names []string
+		for _, entry := range Sorted(em.GetManifest()) {
+			names = append(names, entry.Name)
+		}
+		Expect(names).To(Equal([]string{"cache.hits", "latency", "requests"}))
+		Expect(Sorted(em.GetManifest())[2].PropertyKeys).To(Equal([]string{"route", "status"}))
+	})
+
+	It("Should render JSON with call sites", func() {
+		var entries []t.MetricManifestEntry
+		Expect(json.Unmarshal([]byte(render(JSON)), &entries)).To(Succeed())
+		Expect(entries).To(HaveLen(3))
+		Expect(entries[0]).To(Equal(t.MetricManifestEntry{
+			Name:       "cache.hits",
+			MetricType: t.COUNT,
+			TypeString: "COUNT",
+			Filename:   "cache.go",
+			LineNo:     42,
+			FuncName:   "Get",
+			Package:    "cache",
+		}))
+
+		var buf bytes.Buffer
+		Expect(WriteJSON(&buf, nil)).To(Succeed())
+		Expect(buf.String()).To(Equal("[]\n"))
+	})
+
+	It("Should render YAML", func() {
+		Expect(render(YAML)).To(Equal(`- name: "cache.hits"
+  metric_type: 0
+  type_string: "COUNT"
+  filename: "cache.go"
+  line_no: 42
+  func_name: "Get"
+  package: "cache"
+- name: "latency"
+  metric_type: 3
+  type_string: "TIMER"
+  description: "Request latency"
+  unit: "ms"
+  owner: "platform"
+  runbook_url: "https://runbooks.example.com/latency"
+  tags:
+    - "slo"
+    - "http"
+- name: "requests"
+  metric_type: 0
+  type_string: "COUNT"
+  property_keys:
+    - "route"
+    - "status"
+`))
+	})
+
+	It("Should render a Markdown table", func() {
+		em.WithEventCardinalityLimit("requests", 100)
+
+		Expect(render(Markdown)).To(Equal("" +
+			"| Name | Type | Unit | Description | Owner | Tags | Properties | Cardinality | Call site |\n" +
+			"| --- | --- | --- | --- | --- | --- | --- | --- | --- |\n" +
+			"| `cache.hits` | COUNT |  |  |  |  |  |  | cache.go:42 (cache.Get) |\n" +
+			"| `latency` | TIMER | ms | Request latency ([runbook](https://runbooks.example.com/latency)) | platform | slo, http |  |  |  |\n" +
+			"| `requests` | COUNT |  |  |  |  | `route`, `status` | 0 / 100 |  |\n"))
+	})
+
+	It("Should report children through a SourceFunc", func() {
+		em.NewSubEmitterWithOptions(emitter.SubEmitterOptions{Prefix: "billing"}).Metric("invoices", t.COUNT)
+
+		Expect(SourceFunc(em.GetManifestWithChildren).GetManifest()).To(HaveLen(4))
+	})
+
+	Describe("Handler", func() {
+		serve := func(method, target, accept string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, target, nil)
+			if accept != "" {
+				req.Header.Set("Accept", accept)
+			}
+			rec := httptest.NewRecorder()
+			Handler(em).ServeHTTP(rec, req)
+			return rec
+		}
+
+		It("Should serve JSON by default", func() {
+			rec := serve(http.MethodGet, "/", "")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(rec.Body.String()).To(Equal(render(JSON)))
+		})
+
+		It("Should negotiate the format from the Accept header", func() {
+			rec := serve(http.MethodGet, "/", "text/html, application/yaml;q=0.5, text/markdown;q=0.9")
+			Expect(rec.Header().Get("Content-Type")).To(Equal("text/markdown; charset=utf-8"))
+			Expect(rec.Body.String()).To(Equal(render(Markdown)))
+
+			rec = serve(http.MethodGet, "/", "text/html, */*;q=0.1")
+			Expect(rec.Header().Get("Content-Type")).To(Equal("application/json"))
+
+			Expect(serve(http.MethodGet, "/", "text/html").Code).To(Equal(http.StatusNotAcceptable))
+		})
+
+		It("Should prefer the format query parameter", func() {
+			rec := serve(http.MethodGet, "/?format=yaml", "application/json")
+			Expect(rec.Header().Get("Content-Type")).To(Equal("application/yaml"))
+			Expect(rec.Body.String()).To(Equal(render(YAML)))
+
+			Expect(serve(http.MethodGet, "/?format=xml", "").Code).To(Equal(http.StatusNotAcceptable))
+		})
+
+		It("Should only answer GET and HEAD", func() {
+			rec := serve(http.MethodHead, "/", "")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.Len()).To(BeZero())
+
+			Expect(serve(http.MethodPost, "/", "").Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package emitter
+
+import (
+	"context"
+	"slices"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// Middleware wraps a backend to observe, rewrite, drop or redirect the events
+// it receives. A middleware must not modify the event it is given; it should
+// pass a modified copy to next instead, as the built-in middlewares do.
+type Middleware func(next t.EventBackend) t.EventBackend
+
+// BackendFunc adapts a function to the types.EventBackend interface.
+type BackendFunc func(ctx context.Context, event *t.Event)
+
+func (f BackendFunc) Emit(ctx context.Context, event *t.Event) {
+	f(ctx, event)
+}
+
+// chain applies middleware to backend so that the first middleware sees
+// events first.
+func chain(backend t.EventBackend, middleware []Middleware) t.EventBackend {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		backend = middleware[i](backend)
+	}
+	return backend
+}
+
+// Use adds middleware that sees every event before it is handed to the
+// emitter's backends, including backends added later. Middleware added by
+// earlier calls runs first. Sub-emitters inherit the emitter's middleware.
+func (e *Emitter) Use(middleware ...Middleware) *Emitter {
+	e.middleware = append(e.middleware, middleware...)
+	e.head = chain(BackendFunc(e.send), e.middleware)
+	return e
+}
+
+// middlewareBackend is a backend with its own middleware, see Chain.
+type middlewareBackend struct {
+	// head is the first middleware, which eventually calls backend
+	head    t.EventBackend
+	backend t.EventBackend
+}
+
+// Chain returns backend with middleware applied to it only, for use with
+// WithEventBackend. The first middleware sees events first. Backends that only
+// implement EmitterBackend can be wrapped with AdaptBackend first.
+func Chain(backend t.EventBackend, middleware ...Middleware) t.EventBackend {
+	return &middlewareBackend{head: chain(backend, slices.Clone(middleware)), backend: backend}
+}
+
+func (m *middlewareBackend) Emit(ctx context.Context, event *t.Event) {
+	m.head.Emit(ctx, event)
+}
+
+// Rename rewrites event names with fn.
+func Rename(fn func(name string) string) Middleware {
+	return func(next t.EventBackend) t.EventBackend {
+		return BackendFunc(func(ctx context.Context, event *t.Event) {
+			ev := *event
+			ev.Name = fn(event.Name)
+			next.Emit(ctx, &ev)
+		})
+	}
+}
+
+// Filter drops the events keep returns false for.
+func Filter(keep func(ctx context.Context, event *t.Event) bool) Middleware {
+	return func(next t.EventBackend) t.EventBackend {
+		return BackendFunc(func(ctx context.Context, event *t.Event) {
+			if keep(ctx, event) {
+				next.Emit(ctx, event)
+			}
+		})
+	}
+}
+
+// StaticProps adds props to every event. The event's own props win.
+func StaticProps(props map[string]interface{}) Middleware {
+	return func(next t.EventBackend) t.EventBackend {
+		return BackendFunc(func(ctx context.Context, event *t.Event) {
+			ev := *event
+			ev.Props = make(map[string]interface{}, len(props)+len(event.Props))
+			for k, v := range props {
+				ev.Props[k] = v
+			}
+			for k, v := range event.Props {
+				ev.Props[k] = v
+			}
+			next.Emit(ctx, &ev)
+		})
+	}
+}
+
+// DropTypes drops metric events of the given metric types. Log events are
+// COUNTs but are never dropped by it; use DropKind for those.
+func DropTypes(metricTypes ...t.MetricType) Middleware {
+	return Filter(func(ctx context.Context, event *t.Event) bool {
+		return event.Kind != t.MetricEvent || !slices.Contains(metricTypes, event.MetricType)
+	})
+}
+
+// DropKind drops every metric event or every log event.
+func DropKind(kind t.EventKind) Middleware {
+	return Filter(func(ctx context.Context, event *t.Event) bool {
+		return event.Kind != kind
+	})
+}
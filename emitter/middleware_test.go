@@ -0,0 +1,138 @@
+package emitter
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+var _ = Describe("Middleware", func() {
+	var ctx context.Context
+	var recorder *eventRecorder
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &eventRecorder{}
+	})
+
+	names := func(r *eventRecorder) []string {
+		var names []string
+		for _, ev := range r.Events() {
+			names = append(names, ev.Name)
+		}
+		return names
+	}
+
+	It("Should run middleware in the order it was added", func() {
+		var order []string
+		trace := func(name string) Middleware {
+			return func(next EventBackend) EventBackend {
+				return BackendFunc(func(ctx context.Context, event *Event) {
+					order = append(order, name)
+					next.Emit(ctx, event)
+				})
+			}
+		}
+		emitter := NewEmitter().Use(trace("a"), trace("b")).Use(trace("c")).WithEventBackend(recorder)
+
+		emitter.Count(ctx, "event", nil, 1)
+
+		Expect(order).To(Equal([]string{"a", "b", "c"}))
+		Expect(recorder.Events()).To(HaveLen(1))
+	})
+
+	It("Should rename, filter and add static props", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).Use(
+			Rename(func(name string) string { return "svc." + name }),
+			Filter(func(ctx context.Context, event *Event) bool { return !strings.HasPrefix(event.Name, "svc.debug") }),
+			StaticProps(map[string]interface{}{"env": "prod", "region": "eu"}),
+		)
+
+		props := map[string]interface{}{"region": "us"}
+		emitter.Count(ctx, "requests", props, 1)
+		emitter.Count(ctx, "debug.requests", nil, 1)
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Name).To(Equal("svc.requests"))
+		Expect(events[0].Props).To(Equal(map[string]interface{}{"env": "prod", "region": "us"}))
+		Expect(props).To(Equal(map[string]interface{}{"region": "us"}))
+	})
+
+	It("Should drop events by metric type and kind", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).Use(DropTypes(COUNT, GAUGE))
+
+		emitter.Count(ctx, "count", nil, 1)
+		emitter.Gauge(ctx, "gauge", nil, 1)
+		emitter.Histogram(ctx, "histogram", nil).Observe(1)
+		emitter.Info("log", nil, "msg")
+		Expect(names(recorder)).To(Equal([]string{"histogram", "log"}))
+
+		logs := &eventRecorder{}
+		emitter = NewEmitter().WithEventBackend(logs).Use(DropKind(MetricEvent))
+		emitter.Count(ctx, "count", nil, 1)
+		emitter.Info("log", nil, "msg")
+		Expect(names(logs)).To(Equal([]string{"log"}))
+	})
+
+	It("Should branch events to other backends", func() {
+		audit := &eventRecorder{}
+		emitter := NewEmitter().WithEventBackend(recorder).Use(func(next EventBackend) EventBackend {
+			return BackendFunc(func(ctx context.Context, event *Event) {
+				if strings.HasPrefix(event.Name, "audit.") {
+					audit.Emit(ctx, event)
+					return
+				}
+				next.Emit(ctx, event)
+			})
+		})
+
+		emitter.Info("audit.login", nil, "msg")
+		emitter.Info("login", nil, "msg")
+
+		Expect(names(audit)).To(Equal([]string{"audit.login"}))
+		Expect(names(recorder)).To(Equal([]string{"login"}))
+	})
+
+	It("Should apply backend middleware to that backend only", func() {
+		metrics := &eventRecorder{}
+		emitter := NewEmitter().
+			WithEventBackend(Chain(metrics, DropKind(LogEvent), Rename(strings.ToUpper))).
+			WithEventBackend(recorder)
+
+		emitter.Count(ctx, "count", nil, 1)
+		emitter.Info("log", nil, "msg")
+
+		Expect(names(metrics)).To(Equal([]string{"COUNT"}))
+		Expect(names(recorder)).To(Equal([]string{"count", "log"}))
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(metrics.flushed).To(BeTrue())
+		Expect(emitter.Stats().Backends[0].Name).To(Equal("*emitter.eventRecorder"))
+	})
+
+	It("Should seed registered events through the middleware", func() {
+		emitter := NewEmitter().WithEventBackend(recorder).Use(Rename(strings.ToUpper))
+
+		emitter.Metric("requests", COUNT)
+
+		Expect(names(recorder)).To(Equal([]string{"REQUESTS"}))
+	})
+
+	It("Should pass middleware on to sub-emitters", func() {
+		parent := NewEmitter().WithEventBackend(recorder).Use(Rename(strings.ToUpper))
+		sub := parent.NewSubEmitter().(*Emitter).Use(StaticProps(map[string]interface{}{"sub": true}))
+
+		sub.Count(ctx, "sub", nil, 1)
+		parent.Count(ctx, "parent", nil, 1)
+
+		events := recorder.Events()
+		Expect(events[0].Name).To(Equal("SUB"))
+		Expect(events[0].Props).To(Equal(map[string]interface{}{"sub": true}))
+		Expect(events[1].Name).To(Equal("PARENT"))
+		Expect(events[1].Props).To(BeNil())
+	})
+})
@@ -0,0 +1,170 @@
+package emitter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// DefaultRedactionMask replaces pattern matches when RedactionOptions.Mask is empty.
+const DefaultRedactionMask = "[REDACTED]"
+
+// RedactionOptions configures a redacting backend, see Redact. Keys apply to
+// props and attrs alike. A key that is denied, or missing from a non-empty
+// AllowKeys, is removed; otherwise a key in HashKeys has its value hashed, and
+// any other string value has its Patterns matches masked.
+type RedactionOptions struct {
+	// DenyKeys are removed from every event
+	DenyKeys []string
+	// AllowKeys, if not empty, are the only keys that are kept
+	AllowKeys []string
+	// HashKeys have their values replaced by a hash, so that events can still
+	// be joined on them without exposing the value
+	HashKeys []string
+	// HashSalt is prepended to values before hashing them
+	HashSalt string
+	// Patterns are masked in string values and in log messages
+	Patterns []*regexp.Regexp
+	// Mask replaces pattern matches, DefaultRedactionMask if empty
+	Mask string
+}
+
+type keyAction int
+
+const (
+	keepKey keyAction = iota
+	dropKey
+	hashKey
+)
+
+// redactingBackend scrubs events before handing them to the backend it wraps.
+type redactingBackend struct {
+	backend  t.EventBackend
+	deny     map[string]struct{}
+	allow    map[string]struct{}
+	hash     map[string]struct{}
+	salt     string
+	patterns []*regexp.Regexp
+	mask     string
+}
+
+func keySet(keys []string) map[string]struct{} {
+	if len(keys) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+// Redact wraps backend so that the events it receives are scrubbed according
+// to opts. Add the result with WithEventBackend; each backend can be wrapped
+// with its own options, so that for example metrics get hashed values while
+// logs get masked ones:
+//
+//	em := emitter.NewEmitter().
+//		WithEventBackend(emitter.Redact(statsdBackend, emitter.RedactionOptions{HashKeys: []string{"email"}})).
+//		WithEventBackend(emitter.Redact(logBackend, emitter.RedactionOptions{Patterns: []*regexp.Regexp{emailPattern}}))
+//
+// Other backends, and the caller's props, are not affected. Backends that only
+// implement EmitterBackend can be wrapped with AdaptBackend first.
+func Redact(backend t.EventBackend, opts RedactionOptions) t.EventBackend {
+	r := &redactingBackend{
+		backend:  backend,
+		deny:     keySet(opts.DenyKeys),
+		allow:    keySet(opts.AllowKeys),
+		hash:     keySet(opts.HashKeys),
+		salt:     opts.HashSalt,
+		patterns: opts.Patterns,
+		mask:     opts.Mask,
+	}
+	if r.mask == "" {
+		r.mask = DefaultRedactionMask
+	}
+	return r
+}
+
+func (r *redactingBackend) action(key string) keyAction {
+	if _, ok := r.deny[key]; ok {
+		return dropKey
+	}
+	if r.allow != nil {
+		if _, ok := r.allow[key]; !ok {
+			return dropKey
+		}
+	}
+	if _, ok := r.hash[key]; ok {
+		return hashKey
+	}
+	return keepKey
+}
+
+func (r *redactingBackend) hashValue(value string) string {
+	sum := sha256.Sum256([]byte(r.salt + value))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (r *redactingBackend) maskString(s string) string {
+	for _, p := range r.patterns {
+		s = p.ReplaceAllLiteralString(s, r.mask)
+	}
+	return s
+}
+
+func (r *redactingBackend) redactProps(props map[string]interface{}) map[string]interface{} {
+	if props == nil {
+		return nil
+	}
+	p := make(map[string]interface{}, len(props))
+	for k, v := range props {
+		switch r.action(k) {
+		case dropKey:
+			// Left out of the copy
+		case hashKey:
+			p[k] = r.hashValue(fmt.Sprintf("%v", v))
+		default:
+			if s, ok := v.(string); ok {
+				v = r.maskString(s)
+			}
+			p[k] = v
+		}
+	}
+	return p
+}
+
+func (r *redactingBackend) redactAttrs(attrs []t.Attr) []t.Attr {
+	if len(attrs) == 0 {
+		return nil
+	}
+	a := make([]t.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		switch r.action(attr.Key) {
+		case dropKey:
+			// Left out of the copy
+		case hashKey:
+			a = append(a, String(attr.Key, r.hashValue(attr.String())))
+		default:
+			if attr.Kind == t.AttrString {
+				attr.Str = r.maskString(attr.Str)
+			}
+			a = append(a, attr)
+		}
+	}
+	return a
+}
+
+// Emit scrubs a copy of the event; the event itself is shared with the other
+// backends and must not be modified.
+func (r *redactingBackend) Emit(ctx context.Context, event *t.Event) {
+	ev := *event
+	ev.Props = r.redactProps(event.Props)
+	ev.Attrs = r.redactAttrs(event.Attrs)
+	ev.Message = r.maskString(event.Message)
+	r.backend.Emit(ctx, &ev)
+}
@@ -0,0 +1,90 @@
+package emitter
+
+import (
+	"context"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+var _ = Describe("Redaction", func() {
+	var ctx context.Context
+	var recorder *eventRecorder
+	email := regexp.MustCompile(`[[:alnum:]._%+-]+@[[:alnum:].-]+`)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &eventRecorder{}
+	})
+
+	It("Should drop denied keys and keys missing from the allowlist", func() {
+		emitter := NewEmitter().WithEventBackend(Redact(recorder, RedactionOptions{
+			DenyKeys:  []string{"token"},
+			AllowKeys: []string{"route", "token", "status"},
+		}))
+
+		emitter.CountAttrs(ctx, "requests", 1, String("token", "secret"), Int("status", 200), String("user", "alice"))
+		emitter.Count(ctx, "requests", map[string]interface{}{"route": "/", "token": "secret", "user": "alice"}, 1)
+
+		events := recorder.Events()
+		Expect(events[0].Attrs).To(Equal([]Attr{Int("status", 200)}))
+		Expect(events[1].Props).To(Equal(map[string]interface{}{"route": "/"}))
+	})
+
+	It("Should mask patterns in string values and messages", func() {
+		emitter := NewEmitter().WithEventBackend(Redact(recorder, RedactionOptions{Patterns: []*regexp.Regexp{email}}))
+
+		emitter.ErrorfContext(ctx, "signup.failed", map[string]interface{}{"email": "alice@example.com", "attempt": 2}, "could not sign up %s", "alice@example.com")
+		emitter.InfoAttrs(ctx, "signup", "welcome", String("contact", "mail bob@example.com"))
+
+		events := recorder.Events()
+		Expect(events[0].Message).To(Equal("could not sign up [REDACTED]"))
+		Expect(events[0].Props).To(Equal(map[string]interface{}{"email": "[REDACTED]", "attempt": 2}))
+		Expect(events[1].Attrs).To(Equal([]Attr{String("contact", "mail [REDACTED]")}))
+	})
+
+	It("Should hash values consistently so they can be joined on", func() {
+		emitter := NewEmitter().WithEventBackend(Redact(recorder, RedactionOptions{HashKeys: []string{"user"}, HashSalt: "pepper"}))
+
+		emitter.Count(ctx, "logins", map[string]interface{}{"user": "alice"}, 1)
+		emitter.CountAttrs(ctx, "logins", 1, String("user", "alice"))
+		emitter.Count(ctx, "logins", map[string]interface{}{"user": "bob"}, 1)
+
+		events := recorder.Events()
+		hashed := events[0].Props["user"]
+		Expect(hashed).To(HaveLen(16))
+		Expect(hashed).NotTo(ContainSubstring("alice"))
+		Expect(events[1].Attrs).To(Equal([]Attr{String("user", hashed.(string))}))
+		Expect(events[2].Props["user"]).NotTo(Equal(hashed))
+	})
+
+	It("Should redact per backend without touching the caller's props", func() {
+		metrics := &eventRecorder{}
+		backend := newGatedBackend()
+		backend.open()
+		emitter := NewEmitter().
+			WithEventBackend(Redact(metrics, RedactionOptions{HashKeys: []string{"email"}})).
+			WithEventBackend(Redact(AdaptBackend(backend), RedactionOptions{Patterns: []*regexp.Regexp{email}})).
+			WithEventBackend(recorder)
+
+		props := map[string]interface{}{"email": "alice@example.com"}
+		emitter.Warn("login", props, "login by alice@example.com")
+
+		Expect(metrics.Events()[0].Props["email"]).To(HaveLen(16))
+		Expect(metrics.Events()[0].Message).To(Equal("login by alice@example.com"))
+		Expect(backend.Props("login")).To(Equal(map[string]interface{}{"email": "[REDACTED]", "_message": "login by [REDACTED]", "_logLevel": "WARN"}))
+		Expect(recorder.Events()[0].Props).To(Equal(map[string]interface{}{"email": "alice@example.com"}))
+		Expect(props).To(Equal(map[string]interface{}{"email": "alice@example.com"}))
+	})
+
+	It("Should be named, flushed and closed as the wrapped backend", func() {
+		emitter := NewEmitter().WithEventBackend(Redact(recorder, RedactionOptions{}))
+
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(recorder.flushed).To(BeTrue())
+		Expect(emitter.Stats().Backends[0].Name).To(Equal("*emitter.eventRecorder"))
+	})
+})
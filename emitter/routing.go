@@ -0,0 +1,251 @@
+package emitter
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// Route sends the events it matches to a named set of backends, see WithRoute.
+// Every condition that is set must match; a Route with no conditions matches
+// every event.
+type Route struct {
+	// Events is a path.Match glob on the event name, e.g. "audit.*"
+	Events string
+	// Kinds, if not empty, restricts the route to metric or log events
+	Kinds []t.EventKind
+	// MetricTypes, if not empty, restricts the route to these metric types.
+	// Log events are COUNTs.
+	MetricTypes []t.MetricType
+	// Props are matched against the event's props and attrs, comparing values
+	// formatted with %v
+	Props map[string]interface{}
+	// Backends are the names given to WithNamedBackend
+	Backends []string
+}
+
+func (r Route) matches(ev *t.Event) bool {
+	if r.Events != "" {
+		if ok, _ := path.Match(r.Events, ev.Name); !ok {
+			return false
+		}
+	}
+	if len(r.Kinds) > 0 && !slices.Contains(r.Kinds, ev.Kind) {
+		return false
+	}
+	if len(r.MetricTypes) > 0 && !slices.Contains(r.MetricTypes, ev.MetricType) {
+		return false
+	}
+	for k, want := range r.Props {
+		var got string
+		if a, ok := findAttr(ev.Attrs, k); ok {
+			got = a.String()
+		} else if v, ok := ev.Props[k]; ok {
+			got = fmt.Sprintf("%v", v)
+		} else {
+			return false
+		}
+		if got != fmt.Sprintf("%v", want) {
+			return false
+		}
+	}
+	return true
+}
+
+// String describes the route's conditions, for debugging.
+func (r Route) String() string {
+	var conds []string
+	if r.Events != "" {
+		conds = append(conds, "events="+r.Events)
+	}
+	for _, k := range r.Kinds {
+		conds = append(conds, "kind="+k.String())
+	}
+	for _, mt := range r.MetricTypes {
+		conds = append(conds, "type="+mt.String())
+	}
+	keys := make([]string, 0, len(r.Props))
+	for k := range r.Props {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		conds = append(conds, fmt.Sprintf("%s=%v", k, r.Props[k]))
+	}
+	if len(conds) == 0 {
+		conds = append(conds, "*")
+	}
+	return fmt.Sprintf("%s -> %s", strings.Join(conds, " "), strings.Join(r.Backends, ","))
+}
+
+// router holds the routes of an emitter. It is copied into sub-emitters.
+type router struct {
+	routes []Route
+	// fallback, if set, receives the events no route matches
+	fallback []string
+}
+
+func (r *router) clone() *router {
+	if r == nil {
+		return nil
+	}
+	return &router{routes: slices.Clone(r.routes), fallback: slices.Clone(r.fallback)}
+}
+
+// backends returns the names of the backends ev is routed to, or false if it
+// goes to every backend.
+func (r *router) backends(ev *t.Event) ([]string, bool) {
+	for _, route := range r.routes {
+		if route.matches(ev) {
+			return route.Backends, true
+		}
+	}
+	if r.fallback != nil {
+		return r.fallback, true
+	}
+	return nil, false
+}
+
+func (e *Emitter) ensureRouter() *router {
+	if e.router == nil {
+		e.router = &router{}
+	}
+	return e.router
+}
+
+// WithNamedBackend adds a backend that routes can refer to by name. The name
+// is also the backend's name in Stats. It panics if the name is already used.
+func (e *Emitter) WithNamedBackend(name string, backend t.EmitterBackend) *Emitter {
+	return e.WithNamedEventBackend(name, AdaptBackend(backend))
+}
+
+// WithNamedEventBackend is WithNamedBackend for an EventBackend.
+func (e *Emitter) WithNamedEventBackend(name string, backend t.EventBackend) *Emitter {
+	e.backendsMu.Lock()
+	defer e.backendsMu.Unlock()
+
+	current := e.loadBackends()
+	for _, entry := range current {
+		if entry.route == name {
+			panic(fmt.Sprintf("Backend %s already added", name))
+		}
+	}
+	entry := e.newBackendEntry(backend)
+	entry.name = name
+	entry.route = name
+	next := make([]*backendEntry, len(current), len(current)+1)
+	copy(next, current)
+	next = append(next, entry)
+	e.backends.Store(&next)
+	e.retain()
+	return e
+}
+
+// WithRoute sends the events route matches only to the backends it names,
+// instead of to every backend. Routes are tried in the order they were added
+// and the first match wins. Events that match no route go to the backends
+// named by WithDefaultRoute or, without one, to every backend. It panics if
+// route.Events is not a valid glob.
+func (e *Emitter) WithRoute(route Route) *Emitter {
+	if _, err := path.Match(route.Events, ""); err != nil {
+		panic(fmt.Sprintf("Invalid route glob %q: %v", route.Events, err))
+	}
+	r := e.ensureRouter()
+	r.routes = append(r.routes, route)
+	return e
+}
+
+// WithDefaultRoute sends the events that match no route to the named backends.
+func (e *Emitter) WithDefaultRoute(backends ...string) *Emitter {
+	e.ensureRouter().fallback = append([]string{}, backends...)
+	return e
+}
+
+// RouteInfo describes a route for debugging, see Routes.
+type RouteInfo struct {
+	Route Route
+	// Default is set for the route added with WithDefaultRoute
+	Default bool
+	// Missing are the route's backend names that no backend was added with
+	Missing []string
+}
+
+func (r RouteInfo) String() string {
+	s := r.Route.String()
+	if r.Default {
+		s = "default " + s
+	}
+	if len(r.Missing) > 0 {
+		s += fmt.Sprintf(" (missing %s)", strings.Join(r.Missing, ","))
+	}
+	return s
+}
+
+// Routes returns the emitter's routes in the order they are tried, followed
+// by the default route if there is one.
+func (e *Emitter) Routes() []RouteInfo {
+	if e.router == nil {
+		return nil
+	}
+	names := make(map[string]struct{})
+	for _, entry := range e.loadBackends() {
+		if entry.route != "" {
+			names[entry.route] = struct{}{}
+		}
+	}
+	info := func(route Route, isDefault bool) RouteInfo {
+		ri := RouteInfo{Route: route, Default: isDefault}
+		for _, name := range route.Backends {
+			if _, ok := names[name]; !ok {
+				ri.Missing = append(ri.Missing, name)
+			}
+		}
+		return ri
+	}
+
+	routes := make([]RouteInfo, 0, len(e.router.routes)+1)
+	for _, route := range e.router.routes {
+		routes = append(routes, info(route, false))
+	}
+	if e.router.fallback != nil {
+		routes = append(routes, info(Route{Backends: e.router.fallback}, true))
+	}
+	return routes
+}
+
+// RouteEvent returns the names of the backends, as reported by Stats, that an
+// event would be sent to.
+func (e *Emitter) RouteEvent(ev *t.Event) []string {
+	var names []string
+	for _, entry := range e.routedBackends(ev) {
+		names = append(names, entry.name)
+	}
+	return names
+}
+
+// route returns the names of the backends ev is routed to, or false if it goes
+// to every backend.
+func (e *Emitter) route(ev *t.Event) ([]string, bool) {
+	if e.router == nil {
+		return nil, false
+	}
+	return e.router.backends(ev)
+}
+
+func (e *Emitter) routedBackends(ev *t.Event) []*backendEntry {
+	entries := e.loadBackends()
+	names, routed := e.route(ev)
+	if !routed {
+		return entries
+	}
+	var matched []*backendEntry
+	for _, entry := range entries {
+		if slices.Contains(names, entry.route) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
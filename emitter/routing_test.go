@@ -0,0 +1,149 @@
+package emitter
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+var _ = Describe("Routing", func() {
+	var ctx context.Context
+	var metrics, logs, audit *eventRecorder
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		metrics = &eventRecorder{}
+		logs = &eventRecorder{}
+		audit = &eventRecorder{}
+	})
+
+	names := func(r *eventRecorder) []string {
+		var names []string
+		for _, ev := range r.Events() {
+			names = append(names, ev.Name)
+		}
+		return names
+	}
+
+	newEmitter := func() *Emitter {
+		return NewEmitter().
+			WithNamedEventBackend("metrics", metrics).
+			WithNamedEventBackend("logs", logs).
+			WithNamedEventBackend("audit", audit)
+	}
+
+	It("Should send every event to every backend without routes", func() {
+		emitter := newEmitter()
+
+		emitter.Count(ctx, "count", nil, 1)
+		emitter.Info("log", nil, "msg")
+
+		Expect(names(metrics)).To(Equal([]string{"count", "log"}))
+		Expect(names(audit)).To(Equal([]string{"count", "log"}))
+		Expect(emitter.Routes()).To(BeNil())
+	})
+
+	It("Should route by name, kind and the first matching route", func() {
+		emitter := newEmitter().
+			WithRoute(Route{Events: "audit.*", Backends: []string{"audit"}}).
+			WithRoute(Route{Kinds: []EventKind{LogEvent}, Backends: []string{"logs"}}).
+			WithRoute(Route{Kinds: []EventKind{MetricEvent}, Backends: []string{"metrics"}})
+
+		emitter.Info("audit.login", nil, "msg")
+		emitter.Count(ctx, "audit.count", nil, 1)
+		emitter.Info("log", nil, "msg")
+		emitter.Count(ctx, "count", nil, 1)
+
+		Expect(names(audit)).To(Equal([]string{"audit.login", "audit.count"}))
+		Expect(names(logs)).To(Equal([]string{"log"}))
+		Expect(names(metrics)).To(Equal([]string{"count"}))
+	})
+
+	It("Should route by metric type and prop value", func() {
+		emitter := newEmitter().
+			WithRoute(Route{MetricTypes: []MetricType{TIMER}, Backends: []string{"metrics"}}).
+			WithRoute(Route{Props: map[string]interface{}{"audit": true}, Backends: []string{"audit", "logs"}}).
+			WithDefaultRoute("logs")
+
+		emitter.EmitDuration(ctx, "timer", nil, 0, TIMER)
+		emitter.Count(ctx, "flagged", map[string]interface{}{"audit": true}, 1)
+		emitter.CountAttrs(ctx, "flagged.attr", 1, Bool("audit", true))
+		emitter.Count(ctx, "unflagged", map[string]interface{}{"audit": false}, 1)
+
+		Expect(names(metrics)).To(Equal([]string{"timer"}))
+		Expect(names(audit)).To(Equal([]string{"flagged", "flagged.attr"}))
+		Expect(names(logs)).To(Equal([]string{"flagged", "flagged.attr", "unflagged"}))
+	})
+
+	It("Should route unnamed backends only the events no route matches", func() {
+		recorder := &eventRecorder{}
+		emitter := newEmitter().WithEventBackend(recorder).
+			WithRoute(Route{Events: "audit.*", Backends: []string{"audit"}})
+
+		emitter.Info("audit.login", nil, "msg")
+		emitter.Info("login", nil, "msg")
+
+		Expect(names(recorder)).To(Equal([]string{"login"}))
+		Expect(names(audit)).To(Equal([]string{"audit.login", "login"}))
+	})
+
+	It("Should describe routes and where an event goes", func() {
+		emitter := newEmitter().
+			WithRoute(Route{Events: "audit.*", Kinds: []EventKind{LogEvent}, Backends: []string{"audit", "archive"}}).
+			WithRoute(Route{MetricTypes: []MetricType{COUNT}, Props: map[string]interface{}{"b": 2, "a": 1}, Backends: []string{"metrics"}}).
+			WithDefaultRoute("logs")
+
+		var described []string
+		for _, route := range emitter.Routes() {
+			described = append(described, route.String())
+		}
+		Expect(described).To(Equal([]string{
+			"events=audit.* kind=LOG -> audit,archive (missing archive)",
+			"type=COUNT a=1 b=2 -> metrics",
+			"default * -> logs",
+		}))
+		Expect(emitter.Routes()[0].Missing).To(Equal([]string{"archive"}))
+
+		Expect(emitter.RouteEvent(&Event{Kind: LogEvent, Name: "audit.login"})).To(Equal([]string{"audit"}))
+		Expect(emitter.RouteEvent(&Event{Name: "other", MetricType: GAUGE})).To(Equal([]string{"logs"}))
+	})
+
+	It("Should name backends in stats and reject duplicate names and bad globs", func() {
+		emitter := newEmitter()
+
+		Expect(emitter.Stats().Backends[1].Name).To(Equal("logs"))
+		Expect(func() { emitter.WithNamedEventBackend("logs", logs) }).To(Panic())
+		Expect(func() { emitter.WithRoute(Route{Events: "["}) }).To(Panic())
+	})
+
+	It("Should copy routes into sub-emitters", func() {
+		parent := newEmitter().WithRoute(Route{Events: "audit.*", Backends: []string{"audit"}})
+		sub := parent.NewSubEmitter().(*Emitter).WithRoute(Route{Backends: []string{"metrics"}})
+
+		sub.Info("audit.login", nil, "msg")
+		sub.Info("login", nil, "msg")
+		parent.Info("parent", nil, "msg")
+
+		Expect(names(audit)).To(Equal([]string{"audit.login", "parent"}))
+		Expect(names(metrics)).To(Equal([]string{"login", "parent"}))
+		Expect(names(logs)).To(Equal([]string{"parent"}))
+	})
+
+	It("Should keep routing to named backends behind async queues", func() {
+		emitter := newEmitter().
+			WithRoute(Route{Events: "audit.*", Backends: []string{"audit"}}).
+			WithRoute(Route{Kinds: []EventKind{MetricEvent}, Backends: []string{"metrics"}}).
+			WithAsync(AsyncOptions{})
+
+		emitter.Info("audit.login", nil, "msg")
+		emitter.Count(ctx, "count", nil, 1)
+		Expect(emitter.Flush(ctx)).To(Succeed())
+
+		Expect(names(audit)).To(Equal([]string{"audit.login"}))
+		Expect(names(metrics)).To(Equal([]string{"count"}))
+		Expect(emitter.Routes()[0].Missing).To(BeEmpty())
+	})
+})
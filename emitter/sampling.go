@@ -0,0 +1,173 @@
+package emitter
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"path"
+	"sync"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// Props set on sampled events for backends that only implement
+// types.EmitterBackend. Backends that count events should scale counts by
+// 1/_rate and must not sample again when _sampled is present.
+const (
+	SampleRateProp = "_rate"
+	SampledProp    = "_sampled"
+)
+
+type sampleRule struct {
+	glob string
+	rate float64
+}
+
+// sampler decides which events to keep. Its configuration is set up front and
+// copied into sub-emitters; the random source is shared.
+type sampler struct {
+	eventRates map[string]float64
+	levelRates map[t.Level]float64
+	rules      []sampleRule
+	rng        *lockedRand
+	// resolved caches the event-level rate, or -1 when only level rates apply
+	resolved sync.Map // map[string]float64
+}
+
+// lockedRand guards a seeded *rand.Rand, which is not safe for concurrent use.
+// A nil rng uses the global source.
+type lockedRand struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func (l *lockedRand) Float64() float64 {
+	if l.rng == nil {
+		return rand.Float64()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rng.Float64()
+}
+
+func newSampler() *sampler {
+	return &sampler{
+		eventRates: make(map[string]float64),
+		levelRates: make(map[t.Level]float64),
+		rng:        &lockedRand{},
+	}
+}
+
+func (s *sampler) clone() *sampler {
+	if s == nil {
+		return nil
+	}
+	return &sampler{
+		eventRates: maps.Clone(s.eventRates),
+		levelRates: maps.Clone(s.levelRates),
+		rules:      append([]sampleRule(nil), s.rules...),
+		rng:        s.rng,
+	}
+}
+
+// eventRate returns the configured rate for event, or -1 when neither a static
+// rate nor a rule matches. Static rates win over rules; rules are matched in
+// the order they were added.
+func (s *sampler) eventRate(event string) float64 {
+	if v, ok := s.resolved.Load(event); ok {
+		return v.(float64)
+	}
+
+	rate := -1.0
+	if r, ok := s.eventRates[event]; ok {
+		rate = r
+	} else {
+		for _, rule := range s.rules {
+			if ok, _ := path.Match(rule.glob, event); ok {
+				rate = rule.rate
+				break
+			}
+		}
+	}
+	s.resolved.Store(event, rate)
+	return rate
+}
+
+// decide returns the effective sample rate for an event and whether to keep it.
+func (s *sampler) decide(ev *t.Event) (float64, bool) {
+	rate := s.eventRate(ev.Name)
+	if rate < 0 {
+		r, ok := s.levelRates[ev.Level]
+		if !ok || ev.Kind != t.LogEvent {
+			return 1, true
+		}
+		rate = r
+	}
+
+	if rate >= 1 {
+		return 1, true
+	}
+	if rate <= 0 {
+		return 0, false
+	}
+	return rate, s.rng.Float64() < rate
+}
+
+func validateRate(rate float64) {
+	if rate < 0 || rate > 1 {
+		panic(fmt.Sprintf("Sample rate %v must be between 0 and 1", rate))
+	}
+}
+
+func (e *Emitter) ensureSampler() *sampler {
+	if e.sampler == nil {
+		e.sampler = newSampler()
+	}
+	e.sampler.resolved.Clear()
+	return e.sampler
+}
+
+// WithSampleRate keeps only the given fraction of event's emissions. A rate of
+// 1 disables sampling for the event, overriding any rule or level rate. On a
+// sub-emitter, event is prefixed like the events it emits.
+func (e *Emitter) WithSampleRate(event string, rate float64) *Emitter {
+	validateRate(rate)
+	e.ensureSampler().eventRates[e.eventName(event)] = rate
+	return e
+}
+
+// WithSampleRule applies rate to every event whose name matches glob, using
+// path.Match syntax (e.g. "cache.*"). Rules are tried in the order they were
+// added and static rates from WithSampleRate take precedence.
+func (e *Emitter) WithSampleRule(glob string, rate float64) *Emitter {
+	validateRate(rate)
+	if _, err := path.Match(glob, ""); err != nil {
+		panic(fmt.Sprintf("Invalid sample rule %q: %v", glob, err))
+	}
+	s := e.ensureSampler()
+	s.rules = append(s.rules, sampleRule{glob: glob, rate: rate})
+	return e
+}
+
+// WithLogLevelSampleRate samples log events at level that have no
+// event-specific rate or matching rule.
+func (e *Emitter) WithLogLevelSampleRate(level t.Level, rate float64) *Emitter {
+	validateRate(rate)
+	e.ensureSampler().levelRates[level] = rate
+	return e
+}
+
+// WithSamplingSeed makes sampling decisions deterministic, which is useful in tests.
+func (e *Emitter) WithSamplingSeed(seed uint64) *Emitter {
+	e.ensureSampler().rng = &lockedRand{rng: rand.New(rand.NewPCG(seed, seed))}
+	return e
+}
+
+// sample applies the sampler to an event. It returns the effective rate and
+// false when the event should be dropped.
+func (e *Emitter) sample(ev *t.Event) (float64, bool) {
+	if e.sampler == nil {
+		return 1, true
+	}
+	return e.sampler.decide(ev)
+}
@@ -0,0 +1,122 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+var _ = Describe("Sampling", func() {
+	var ctx context.Context
+	var backend *gatedBackend
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		backend = newGatedBackend()
+		backend.open()
+	})
+
+	emitN := func(emitter *Emitter, n int) []string {
+		for i := 0; i < n; i++ {
+			emitter.Count(ctx, fmt.Sprintf("event.%d", i), nil, 1)
+		}
+		return backend.Events()
+	}
+
+	It("Should drop events with a rate of 0 and keep events with a rate of 1", func() {
+		emitter := NewEmitter(backend).WithSampleRate("dropped", 0).WithSampleRate("kept", 1)
+
+		emitter.Count(ctx, "dropped", nil, 1)
+		emitter.Count(ctx, "kept", nil, 1)
+		emitter.Count(ctx, "other", nil, 1)
+
+		Expect(backend.Events()).To(Equal([]string{"kept", "other"}))
+		Expect(backend.Props("kept")).NotTo(HaveKey(SampleRateProp))
+	})
+
+	It("Should attach the effective rate to kept events", func() {
+		emitter := NewEmitter(backend).WithSampleRule("event.*", 0.5).WithSamplingSeed(1)
+
+		kept := emitN(emitter, 100)
+		Expect(kept).NotTo(BeEmpty())
+		Expect(backend.Props(kept[0])).To(HaveKeyWithValue(SampleRateProp, 0.5))
+		Expect(backend.Props(kept[0])).To(HaveKeyWithValue(SampledProp, true))
+	})
+
+	It("Should make the same decisions for the same seed", func() {
+		first := emitN(NewEmitter(backend).WithSampleRule("event.*", 0.5).WithSamplingSeed(42), 200)
+
+		backend = newGatedBackend()
+		backend.open()
+		second := emitN(NewEmitter(backend).WithSampleRule("event.*", 0.5).WithSamplingSeed(42), 200)
+
+		Expect(second).To(Equal(first))
+	})
+
+	It("Should keep roughly the configured fraction of events", func() {
+		emitter := NewEmitter(backend).WithSampleRule("*", 0.1).WithSamplingSeed(7)
+		Expect(len(emitN(emitter, 2000))).To(BeNumerically("~", 200, 60))
+	})
+
+	It("Should prefer static rates over rules and earlier rules over later ones", func() {
+		emitter := NewEmitter(backend).
+			WithSampleRule("cache.*", 0).
+			WithSampleRule("cache.hit", 1).
+			WithSampleRule("db.*", 1).
+			WithSampleRule("*", 0).
+			WithSampleRate("cache.miss", 1)
+
+		emitter.Count(ctx, "cache.hit", nil, 1)
+		emitter.Count(ctx, "cache.miss", nil, 1)
+		emitter.Count(ctx, "db.query", nil, 1)
+		emitter.Count(ctx, "other", nil, 1)
+
+		Expect(backend.Events()).To(Equal([]string{"cache.miss", "db.query"}))
+	})
+
+	It("Should apply level rates to logs without an event rate", func() {
+		emitter := NewEmitter(backend).
+			WithLogLevelSampleRate(LevelDebug, 0).
+			WithSampleRate("important", 1)
+
+		emitter.Debug("noisy", nil, "dropped")
+		emitter.Debug("important", nil, "kept")
+		emitter.Info("noisy.info", nil, "kept")
+		// Level rates don't apply to metrics
+		emitter.Count(ctx, "noisy.metric", nil, 1)
+
+		Expect(backend.Events()).To(Equal([]string{"important", "noisy.info", "noisy.metric"}))
+	})
+
+	It("Should not sample again in stacked emitters", func() {
+		inner := NewEmitter(backend).WithSampleRule("*", 0.01)
+		outer := NewEmitter(inner).WithSampleRule("*", 0.5).WithSamplingSeed(3)
+
+		for i := 0; i < 100; i++ {
+			outer.Count(ctx, "event", nil, 1)
+		}
+		Expect(backend.Value("event")).To(Equal(int64(outer.Stats().Emitted)))
+		Expect(backend.Props("event")).To(HaveKeyWithValue(SampleRateProp, 0.5))
+	})
+
+	It("Should copy sampling configuration into sub-emitters", func() {
+		parent := NewEmitter(backend).WithSampleRate("event", 0)
+		sub := parent.NewSubEmitter().(*Emitter).WithSampleRate("sub.event", 0)
+
+		sub.Count(ctx, "event", nil, 1)
+		sub.Count(ctx, "sub.event", nil, 1)
+		parent.Count(ctx, "sub.event", nil, 1)
+
+		Expect(backend.Events()).To(Equal([]string{"sub.event"}))
+	})
+
+	It("Should reject invalid rates and rules", func() {
+		Expect(func() { NewEmitter().WithSampleRate("event", 1.5) }).To(Panic())
+		Expect(func() { NewEmitter().WithLogLevelSampleRate(LevelInfo, -1) }).To(Panic())
+		Expect(func() { NewEmitter().WithSampleRule("[", 0.5) }).To(Panic())
+	})
+})
@@ -50,13 +50,13 @@ func NewStaticEmitter(base *Emitter, callsites map[string]t.CallSiteDetails) *Em
 		// Get hostname from the base emitter's provider
 		hostname, _ := base.hostname_provider()
 
-		base.memoTable[eventName] = eventCallSiteProps{
+		base.memoTable.Store(eventName, eventCallSiteProps{
 			hostname: hostname,
 			filename: details.Filename,
 			lineNo:   details.LineNo,
 			funcName: details.FuncName,
 			package_: details.Package,
-		}
+		})
 	}
 
 	return base
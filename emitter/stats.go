@@ -0,0 +1,124 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// BackendError is passed to the handler registered with WithErrorHandler when a
+// backend fails to emit an event.
+type BackendError struct {
+	// Backend names the backend that failed, see BackendStats.Name
+	Backend string
+	Event   string
+	Err     error
+}
+
+func (b *BackendError) Error() string {
+	return fmt.Sprintf("backend %s failed to emit %s: %v", b.Backend, b.Event, b.Err)
+}
+
+func (b *BackendError) Unwrap() error {
+	return b.Err
+}
+
+// BackendStats are the self-observability counters for a single backend.
+type BackendStats struct {
+	// Name is the name given to WithNamedBackend or else the Go type of the
+	// backend, e.g. "*statsd.StatsdBackend"
+	Name string
+	// Emitted counts events handed to the backend, or queued for it in async mode
+	Emitted uint64
+	// Errors counts failures the backend reported through types.ErrorReporter
+	Errors uint64
+	// Dropped counts events the backend's async queue discarded
+	Dropped uint64
+}
+
+// Stats is a snapshot of an emitter's self-observability counters.
+type Stats struct {
+	// Emitted counts the events emitted through this emitter
+	Emitted  uint64
+	Errors   uint64
+	Dropped  uint64
+	Backends []BackendStats
+}
+
+type backendStats struct {
+	emitted atomic.Uint64
+	errors  atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// backendEntry pairs a backend with its counters. Entries are shared between
+// an emitter and its sub-emitters, so counters follow the backend.
+type backendEntry struct {
+	// backend receives the events; it may be an adapter or an async queue
+	// wrapping the backend that was added, see unwrapBackend.
+	backend t.EventBackend
+	name    string
+	// route is the name given to WithNamedBackend, empty for unnamed backends
+	route string
+	stats *backendStats
+}
+
+// newBackendEntry creates the entry for backend and, if the backend can report
+// errors, routes them to the entry's counters and the emitter's error handler.
+func (e *Emitter) newBackendEntry(backend t.EventBackend) *backendEntry {
+	source := unwrapBackend(backend)
+	entry := &backendEntry{
+		backend: backend,
+		name:    fmt.Sprintf("%T", source),
+		stats:   &backendStats{},
+	}
+	if e.async != nil {
+		entry.backend = newAsyncBackend(backend, *e.async, entry.stats)
+	}
+	if reporter, ok := source.(t.ErrorReporter); ok {
+		reporter.SetErrorHandler(func(ctx context.Context, event string, err error) {
+			entry.stats.errors.Add(1)
+			e.handleError(ctx, &BackendError{Backend: entry.name, Event: event, Err: err})
+		})
+	}
+	return entry
+}
+
+func (e *Emitter) handleError(ctx context.Context, err *BackendError) {
+	if e.errorHandler != nil {
+		e.errorHandler(ctx, err)
+	}
+}
+
+// WithErrorHandler registers a hook that receives every error reported by the
+// emitter's backends as a *BackendError. Backends opt in to error reporting by
+// implementing types.ErrorReporter. The handler may be called from async queue
+// workers, so it must be safe for concurrent use.
+func (e *Emitter) WithErrorHandler(handler func(ctx context.Context, err error)) *Emitter {
+	e.errorHandler = handler
+	return e
+}
+
+// Stats returns a snapshot of the emitter's own counters and those of each of
+// its backends, in the order the backends were added.
+func (e *Emitter) Stats() Stats {
+	entries := e.loadBackends()
+	stats := Stats{
+		Emitted:  e.emitted.Load(),
+		Backends: make([]BackendStats, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		b := BackendStats{
+			Name:    entry.name,
+			Emitted: entry.stats.emitted.Load(),
+			Errors:  entry.stats.errors.Load(),
+			Dropped: entry.stats.dropped.Load(),
+		}
+		stats.Errors += b.Errors
+		stats.Dropped += b.Dropped
+		stats.Backends = append(stats.Backends, b)
+	}
+	return stats
+}
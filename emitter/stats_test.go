@@ -0,0 +1,126 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// failingBackend reports err for every emission through its error handler.
+type failingBackend struct {
+	err     error
+	onError ErrorHandler
+}
+
+func (f *failingBackend) SetErrorHandler(handler ErrorHandler) {
+	f.onError = handler
+}
+
+func (f *failingBackend) EmitInt(ctx context.Context, event string, props map[string]interface{}, value int64, metricType MetricType) {
+	f.onError(ctx, event, f.err)
+}
+
+func (f *failingBackend) EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType MetricType) {
+	f.onError(ctx, event, f.err)
+}
+
+func (f *failingBackend) EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType MetricType) {
+	f.onError(ctx, event, f.err)
+}
+
+var _ = Describe("Error reporting and stats", func() {
+	var ctx context.Context
+	var backendErr error
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		backendErr = errors.New("socket closed")
+	})
+
+	It("Should pass backend errors to the error handler", func() {
+		var mu sync.Mutex
+		var handled []error
+		emitter := NewEmitter(&failingBackend{err: backendErr}).WithErrorHandler(func(_ context.Context, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, err)
+		})
+
+		emitter.Count(ctx, "event", nil, 1)
+
+		Expect(handled).To(HaveLen(1))
+		Expect(handled[0]).To(MatchError(backendErr))
+		var be *BackendError
+		Expect(errors.As(handled[0], &be)).To(BeTrue())
+		Expect(be.Backend).To(Equal("*emitter.failingBackend"))
+		Expect(be.Event).To(Equal("event"))
+	})
+
+	It("Should count errors without an error handler", func() {
+		emitter := NewEmitter(&failingBackend{err: backendErr})
+		emitter.Count(ctx, "event", nil, 1)
+		emitter.Gauge(ctx, "event", nil, 1)
+
+		stats := emitter.Stats()
+		Expect(stats.Errors).To(Equal(uint64(2)))
+		Expect(stats.Backends[0].Errors).To(Equal(uint64(2)))
+	})
+
+	It("Should count emitted events per backend", func() {
+		first := newGatedBackend()
+		first.open()
+		second := &failingBackend{err: backendErr}
+		emitter := NewEmitter(first).WithBackend(second)
+
+		emitter.Count(ctx, "a", nil, 1)
+		emitter.Gauge(ctx, "b", nil, 1)
+		emitter.EmitDuration(ctx, "c", nil, time.Second, TIMER)
+
+		Expect(emitter.Stats()).To(Equal(Stats{
+			Emitted: 3,
+			Errors:  3,
+			Backends: []BackendStats{
+				{Name: "*emitter.gatedBackend", Emitted: 3},
+				{Name: "*emitter.failingBackend", Emitted: 3, Errors: 3},
+			},
+		}))
+	})
+
+	It("Should count async drops per backend", func() {
+		backend := newGatedBackend()
+		emitter := NewEmitter(backend).WithAsync(AsyncOptions{QueueSize: 1, Overflow: OverflowDropNewest})
+
+		emitter.Count(ctx, "first", nil, 1)
+		Eventually(func() int { return len(emitter.asyncBackends()[0].jobs) }).Should(Equal(0))
+		emitter.Count(ctx, "second", nil, 1)
+		emitter.Count(ctx, "third", nil, 1)
+
+		stats := emitter.Stats()
+		Expect(stats.Dropped).To(Equal(uint64(1)))
+		Expect(stats.Backends[0].Name).To(Equal("*emitter.gatedBackend"))
+		Expect(stats.Backends[0].Dropped).To(Equal(uint64(1)))
+
+		backend.open()
+		Expect(emitter.Shutdown(ctx)).To(Succeed())
+	})
+
+	It("Should share backend counters with sub-emitters", func() {
+		backend := newGatedBackend()
+		backend.open()
+		parent := NewEmitter(backend)
+		sub := parent.NewSubEmitter().(*Emitter)
+
+		parent.Count(ctx, "parent", nil, 1)
+		sub.Count(ctx, "sub", nil, 1)
+
+		Expect(parent.Stats().Emitted).To(Equal(uint64(1)))
+		Expect(sub.Stats().Emitted).To(Equal(uint64(1)))
+		Expect(parent.Stats().Backends[0].Emitted).To(Equal(uint64(2)))
+	})
+})
@@ -0,0 +1,144 @@
+package emitter
+
+import (
+	"maps"
+	"slices"
+	"weak"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// DefaultPrefixSeparator joins a sub-emitter's prefix to its event names when
+// SubEmitterOptions.Separator is empty.
+const DefaultPrefixSeparator = "."
+
+// SubEmitterOptions configures NewSubEmitterWithOptions.
+type SubEmitterOptions struct {
+	// Prefix is prepended to the name of every event emitted or registered
+	// through the sub-emitter, e.g. "billing" turns "invoice_created" into
+	// "billing.invoice_created". Prefixes of nested sub-emitters are joined.
+	Prefix string
+	// Separator joins Prefix to event names, DefaultPrefixSeparator if empty
+	Separator string
+	// Props are merged into every event. Context props and the props passed
+	// with the event win over them. They are added to the parent's props.
+	Props map[string]interface{}
+}
+
+// NewSubEmitterWithOptions is NewSubEmitter for a sub-emitter that namespaces
+// its events and adds default props to them:
+//
+//	billing := em.NewSubEmitterWithOptions(emitter.SubEmitterOptions{
+//		Prefix: "billing",
+//		Props:  map[string]interface{}{"team": "payments"},
+//	})
+//
+//	billing.Count(ctx, "invoice_created", nil, 1) // billing.invoice_created
+//
+// The prefix applies to the names given to the emit, log, registration and
+// Enabled methods, and to the per-event settings SetEventLevel,
+// ClearEventLevel, WithSampleRate, WithEventCardinalityLimit and Cardinality,
+// so a sub-emitter is configured with the names it emits. Globs and routes, as
+// in WithSampleRule and WithRoute, match full names. Events the emitter
+// reports about itself are not prefixed.
+func (e *Emitter) NewSubEmitterWithOptions(opts SubEmitterOptions) *Emitter {
+	sub := e.NewSubEmitter().(*Emitter)
+	if opts.Prefix != "" {
+		sep := opts.Separator
+		if sep == "" {
+			sep = DefaultPrefixSeparator
+		}
+		sub.prefix = e.prefix + opts.Prefix + sep
+	}
+	if len(opts.Props) > 0 {
+		props := make(map[string]interface{}, len(e.defaultProps)+len(opts.Props))
+		maps.Copy(props, e.defaultProps)
+		maps.Copy(props, opts.Props)
+		sub.defaultProps = props
+	}
+	return sub
+}
+
+// Prefix returns the prefix, including its separator, that the emitter adds to
+// event names.
+func (e *Emitter) Prefix() string {
+	return e.prefix
+}
+
+// eventName returns the full name of event.
+func (e *Emitter) eventName(event string) string {
+	if e.prefix == "" {
+		return event
+	}
+	return e.prefix + event
+}
+
+// childRef is a parent's reference to one of its sub-emitters. It only holds
+// the sub-emitter weakly until retain makes it strong.
+type childRef struct {
+	weak   weak.Pointer[Emitter]
+	strong *Emitter
+}
+
+// appendChild adds ref to children. Before the slice grows, references to
+// collected sub-emitters are pruned, so that it stays proportional to the
+// number of live sub-emitters.
+func appendChild(children []*childRef, ref *childRef) []*childRef {
+	if len(children) == cap(children) {
+		children = slices.DeleteFunc(children, func(c *childRef) bool {
+			return c.strong == nil && c.weak.Value() == nil
+		})
+	}
+	return append(children, ref)
+}
+
+// retain makes the parent, and its own parents, keep the sub-emitter alive,
+// because it now owns backends or queues that Flush and Shutdown on them must
+// reach.
+func (e *Emitter) retain() {
+	for sub := e; sub.parent != nil; sub = sub.parent {
+		sub.parent.mu.Lock()
+		sub.ref.strong = sub
+		sub.parent.mu.Unlock()
+	}
+}
+
+// subEmitters returns the emitter's sub-emitters that are still alive.
+func (e *Emitter) subEmitters() []*Emitter {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	subs := make([]*Emitter, 0, len(e.children))
+	for _, c := range e.children {
+		if c.strong != nil {
+			subs = append(subs, c.strong)
+		} else if sub := c.weak.Value(); sub != nil {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// GetManifestWithChildren is GetManifest including the events registered on
+// the emitter's sub-emitters, and theirs, under their full prefixed names. An
+// event registered more than once is reported as it was registered closest to
+// this emitter.
+// Sub-emitters that have been garbage collected are no longer included.
+func (e *Emitter) GetManifestWithChildren() []t.MetricManifestEntry {
+	seen := make(map[string]struct{})
+	var manifest []t.MetricManifestEntry
+	var collect func(em *Emitter)
+	collect = func(em *Emitter) {
+		for _, entry := range em.GetManifest() {
+			if _, ok := seen[entry.Name]; ok {
+				continue
+			}
+			seen[entry.Name] = struct{}{}
+			manifest = append(manifest, entry)
+		}
+		for _, child := range em.subEmitters() {
+			collect(child)
+		}
+	}
+	collect(e)
+	return manifest
+}
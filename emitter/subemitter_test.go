@@ -0,0 +1,148 @@
+package emitter
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+var _ = Describe("Sub-emitter options", func() {
+	var ctx context.Context
+	var recorder *eventRecorder
+	var parent *Emitter
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &eventRecorder{}
+		parent = NewEmitter().WithEventBackend(recorder)
+	})
+
+	names := func() []string {
+		var names []string
+		for _, ev := range recorder.Events() {
+			names = append(names, ev.Name)
+		}
+		return names
+	}
+
+	It("Should prefix emitted, logged and registered events", func() {
+		billing := parent.NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "billing"})
+
+		billing.Count(ctx, "invoice_created", nil, 1)
+		billing.Info("started", nil, "msg")
+		billing.CountAttrs(ctx, "attrs", 1, String("k", "v"))
+		billing.Metric("registered", COUNT)
+		RegisterCounter[struct{}](billing, "typed").Inc(ctx, struct{}{})
+		parent.Count(ctx, "parent", nil, 1)
+
+		Expect(names()).To(Equal([]string{
+			"billing.invoice_created",
+			"billing.started",
+			"billing.attrs",
+			"billing.registered", // seeded
+			"billing.typed",      // seeded
+			"billing.typed",
+			"parent",
+		}))
+		Expect(billing.Prefix()).To(Equal("billing."))
+	})
+
+	It("Should join nested prefixes with their separators", func() {
+		billing := parent.NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "billing"})
+		invoices := billing.NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "invoices", Separator: "_"})
+		plain := invoices.NewSubEmitter().(*Emitter)
+
+		invoices.Count(ctx, "created", nil, 1)
+		plain.Count(ctx, "paid", nil, 1)
+
+		Expect(names()).To(Equal([]string{"billing.invoices_created", "billing.invoices_paid"}))
+	})
+
+	It("Should merge default props below context and explicit props", func() {
+		billing := parent.NewSubEmitterWithOptions(SubEmitterOptions{
+			Props: map[string]interface{}{"team": "payments", "env": "prod", "tier": "gold"},
+		})
+		child := billing.NewSubEmitterWithOptions(SubEmitterOptions{Props: map[string]interface{}{"tier": "silver"}})
+
+		ctx = ContextWithProps(ctx, map[string]interface{}{"env": "staging"})
+		child.Count(ctx, "event", map[string]interface{}{"team": "ledger"}, 1)
+		parent.Count(ctx, "parent", nil, 1)
+
+		events := recorder.Events()
+		Expect(events[0].Props).To(Equal(map[string]interface{}{"team": "ledger", "env": "staging", "tier": "silver"}))
+		Expect(events[1].Props).To(Equal(map[string]interface{}{"env": "staging"}))
+	})
+
+	It("Should check levels by full name", func() {
+		billing := parent.NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "billing"})
+		parent.SetEventLevel("billing.noisy", LevelError)
+
+		Expect(billing.Enabled("noisy", LevelInfo)).To(BeFalse())
+		Expect(billing.Enabled("quiet", LevelInfo)).To(BeTrue())
+
+		billing.Info("noisy", nil, "msg")
+		billing.Error("noisy", nil, "msg")
+		Expect(names()).To(Equal([]string{"billing.noisy"}))
+	})
+
+	It("Should configure per-event settings with the names it emits", func() {
+		parent.SetLevel(LevelInfo)
+		billing := parent.NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "billing"})
+		billing.SetEventLevel("dbg", LevelDebug)
+		billing.WithSampleRate("dropped", 0)
+		billing.WithEventCardinalityLimit("capped", 1)
+
+		billing.Debug("dbg", nil, "msg")
+		billing.Count(ctx, "dropped", nil, 1)
+		billing.Count(ctx, "capped", map[string]interface{}{"k": "a"}, 1)
+		Expect(names()).To(Equal([]string{"billing.dbg", "billing.capped"}))
+		Expect(parent.Enabled("billing.dbg", LevelDebug)).To(BeTrue())
+
+		current, limit := billing.Cardinality("capped")
+		Expect(current).To(Equal(1))
+		Expect(limit).To(Equal(1))
+
+		billing.ClearEventLevel("dbg")
+		Expect(billing.Enabled("dbg", LevelDebug)).To(BeFalse())
+	})
+
+	It("Should report children's events in the parent's manifest", func() {
+		parent.Metric("requests", COUNT)
+		billing := parent.NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "billing"})
+		billing.Metric("invoice_created", COUNT)
+		billing.NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "tax"}).Metric("computed", GAUGE)
+
+		Expect(parent.GetManifest()).To(HaveLen(1))
+
+		var manifest []string
+		for _, entry := range parent.GetManifestWithChildren() {
+			manifest = append(manifest, entry.Name)
+		}
+		Expect(manifest).To(Equal([]string{"requests", "billing.invoice_created", "billing.tax.computed"}))
+		Expect(billing.GetManifest()[0].Name).To(Equal("billing.invoice_created"))
+	})
+
+	It("Should look up static call sites by the unprefixed name", func() {
+		static := map[string]CallSiteDetails{
+			"invoice_created": {Filename: "billing.go", LineNo: 42, FuncName: "billing.Create", MetricType: "COUNT"},
+		}
+		parent.WithCallsiteProvider(StaticCallsiteProvider(static)).WithMagicLineNo()
+		billing := parent.NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "billing"})
+		billing.WithStaticMetadata(static)
+
+		billing.Count(ctx, "invoice_created", nil, 1)
+
+		event := recorder.Events()[0]
+		Expect(event.Name).To(Equal("billing.invoice_created"))
+		Expect(event.CallSite.LineNo).To(Equal(42))
+		Expect(event.Props).To(HaveKeyWithValue("lineNo", 42))
+
+		manifest := billing.GetManifest()
+		Expect(manifest).To(HaveLen(1))
+		Expect(manifest[0].Name).To(Equal("billing.invoice_created"))
+		Expect(manifest[0].Filename).To(Equal("billing.go"))
+	})
+})
@@ -0,0 +1,158 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// SuppressionOptions configures log deduplication. See Emitter.WithLogSuppression.
+type SuppressionOptions struct {
+	// Window is how long repeats of a log are tracked. Defaults to one second.
+	Window time.Duration
+	// Burst is the number of identical logs let through per window. Defaults to 1.
+	Burst int
+	// KeyProps are the props, besides the event name and level, that identify
+	// a log. Values are looked up in the explicit props, then the context props.
+	KeyProps []string
+}
+
+const defaultSuppressionWindow = time.Second
+
+// suppressionWindow tracks one log identity from its first occurrence until
+// its window closes.
+type suppressionWindow struct {
+	seen       int
+	suppressed int64
+	timer      *time.Timer
+
+	// The first suppressed occurrence, reused for the summary
+	emitter *Emitter
+	ctx     context.Context
+	event   t.Event
+}
+
+// suppressor is shared between an emitter and its sub-emitters, so repeats are
+// counted across all of them.
+type suppressor struct {
+	opts    SuppressionOptions
+	mu      sync.Mutex
+	windows map[string]*suppressionWindow
+}
+
+func newSuppressor(opts SuppressionOptions) *suppressor {
+	if opts.Window <= 0 {
+		opts.Window = defaultSuppressionWindow
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = 1
+	}
+	return &suppressor{opts: opts, windows: make(map[string]*suppressionWindow)}
+}
+
+func (s *suppressor) key(ctx context.Context, ev *t.Event) string {
+	var b strings.Builder
+	b.WriteString(ev.Level.String())
+	b.WriteByte(0)
+	b.WriteString(ev.Name)
+	ctxProps := PropsFromContext(ctx)
+	for _, k := range s.opts.KeyProps {
+		b.WriteByte(0)
+		if a, ok := findAttr(ev.Attrs, k); ok {
+			b.WriteString(a.String())
+			continue
+		}
+		v, ok := ev.Props[k]
+		if !ok {
+			v, ok = ctxProps[k]
+		}
+		if ok {
+			fmt.Fprintf(&b, "%v", v)
+		}
+	}
+	return b.String()
+}
+
+// allow reports whether a log may be emitted. Logs past the burst are counted
+// and reported by a single summary log when the window closes.
+func (s *suppressor) allow(e *Emitter, ctx context.Context, ev *t.Event) bool {
+	key := s.key(ctx, ev)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.windows[key]
+	if !ok {
+		w = &suppressionWindow{}
+		w.timer = time.AfterFunc(s.opts.Window, func() { s.close(key, w) })
+		s.windows[key] = w
+	}
+	w.seen++
+	if w.seen <= s.opts.Burst {
+		return true
+	}
+
+	w.suppressed++
+	if w.suppressed == 1 {
+		w.emitter = e
+		w.ctx = context.WithoutCancel(ctx)
+		w.event = *ev
+		// The caller may reuse its props map and attrs once we return
+		w.event.Props = maps.Clone(ev.Props)
+		w.event.Attrs = slices.Clone(ev.Attrs)
+	}
+	return false
+}
+
+func (s *suppressor) close(key string, w *suppressionWindow) {
+	s.mu.Lock()
+	if s.windows[key] != w {
+		// Already closed by flush
+		s.mu.Unlock()
+		return
+	}
+	delete(s.windows, key)
+	s.mu.Unlock()
+
+	w.report()
+}
+
+// flush closes every open window, reporting pending summaries.
+func (s *suppressor) flush() {
+	s.mu.Lock()
+	windows := s.windows
+	s.windows = make(map[string]*suppressionWindow)
+	s.mu.Unlock()
+
+	for _, w := range windows {
+		w.timer.Stop()
+		w.report()
+	}
+}
+
+// report emits the summary log. Its COUNT value is the number of suppressed
+// occurrences, so counting backends still see every occurrence of the event.
+func (w *suppressionWindow) report() {
+	if w.suppressed == 0 {
+		return
+	}
+	w.event.Message = fmt.Sprintf("suppressed %d occurrences", w.suppressed)
+	w.event.Int = w.suppressed
+	w.emitter.dispatch(w.ctx, &w.event)
+}
+
+// WithLogSuppression deduplicates bursts of identical logs. A log is identified
+// by its event name, level and the values of opts.KeyProps. Within a window only
+// the first opts.Burst occurrences are emitted; when the window closes, or on
+// Flush, a summary log "suppressed K occurrences" is emitted for the rest.
+// Metric events are never suppressed.
+func (e *Emitter) WithLogSuppression(opts SuppressionOptions) *Emitter {
+	e.suppressor = newSuppressor(opts)
+	return e
+}
@@ -0,0 +1,85 @@
+package emitter
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Log suppression", func() {
+	var ctx context.Context
+	var backend *gatedBackend
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		backend = newGatedBackend()
+		backend.open()
+	})
+
+	It("Should drop repeats past the burst and summarize them on Flush", func() {
+		emitter := NewEmitter(backend).WithLogSuppression(SuppressionOptions{Window: time.Hour, Burst: 2})
+
+		for i := 0; i < 5; i++ {
+			emitter.ErrorfContext(ctx, "db.down", nil, "attempt %d failed", i)
+		}
+		Expect(backend.Events()).To(HaveLen(2))
+
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(backend.Events()).To(HaveLen(3))
+		Expect(backend.Props("db.down")).To(HaveKeyWithValue("_message", "suppressed 3 occurrences"))
+		Expect(backend.Props("db.down")).To(HaveKeyWithValue("_logLevel", "ERROR"))
+		// The summary carries the suppressed count, so counts stay exact
+		Expect(backend.Value("db.down")).To(Equal(int64(5)))
+	})
+
+	It("Should summarize when the window closes and then allow the log again", func() {
+		emitter := NewEmitter(backend).WithLogSuppression(SuppressionOptions{Window: 20 * time.Millisecond})
+
+		for i := 0; i < 3; i++ {
+			emitter.Error("db.down", nil, "failed")
+		}
+		Expect(backend.Events()).To(HaveLen(1))
+		Eventually(backend.Events).Should(HaveLen(2))
+		Expect(backend.Props("db.down")).To(HaveKeyWithValue("_message", "suppressed 2 occurrences"))
+
+		emitter.Error("db.down", nil, "failed")
+		Expect(backend.Events()).To(HaveLen(3))
+		Expect(backend.Props("db.down")).To(HaveKeyWithValue("_message", "failed"))
+	})
+
+	It("Should identify logs by event, level and key props", func() {
+		emitter := NewEmitter(backend).WithLogSuppression(SuppressionOptions{Window: time.Hour, KeyProps: []string{"host"}})
+		hostCtx := ContextWithProps(ctx, map[string]interface{}{"host": "b"})
+
+		emitter.ErrorContext(ctx, "db.down", map[string]interface{}{"host": "a"}, "failed")
+		emitter.ErrorContext(ctx, "db.down", map[string]interface{}{"host": "a", "attempt": 2}, "failed")
+		emitter.ErrorContext(hostCtx, "db.down", nil, "failed")
+		emitter.WarnContext(ctx, "db.down", map[string]interface{}{"host": "a"}, "failed")
+		emitter.ErrorContext(ctx, "cache.down", map[string]interface{}{"host": "a"}, "failed")
+
+		Expect(backend.Events()).To(HaveLen(4))
+	})
+
+	It("Should never suppress metrics", func() {
+		emitter := NewEmitter(backend).WithLogSuppression(SuppressionOptions{Window: time.Hour})
+
+		for i := 0; i < 10; i++ {
+			emitter.Count(ctx, "db.errors", nil, 1)
+		}
+		Expect(backend.Value("db.errors")).To(Equal(int64(10)))
+	})
+
+	It("Should count repeats across sub-emitters", func() {
+		emitter := NewEmitter(backend).WithLogSuppression(SuppressionOptions{Window: time.Hour})
+		sub := emitter.NewSubEmitter().(*Emitter)
+
+		emitter.Error("db.down", nil, "failed")
+		sub.Error("db.down", nil, "failed")
+		Expect(backend.Events()).To(HaveLen(1))
+
+		Expect(emitter.Flush(ctx)).To(Succeed())
+		Expect(backend.Value("db.down")).To(Equal(int64(2)))
+	})
+})
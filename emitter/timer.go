@@ -0,0 +1,152 @@
+package emitter
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// LapSeparator joins a timer's event name and the name of a lap, so
+// Lap("parse") on a "request" timer emits "request.parse".
+const LapSeparator = "."
+
+// Timer is a running TIMER started by Emitter.StartTimer. It can be passed
+// around and stopped from another function or goroutine.
+type Timer struct {
+	emitter *Emitter
+	ctx     context.Context
+	event   string
+	props   map[string]interface{}
+	start   time.Time
+
+	mu      sync.Mutex
+	lap     time.Time
+	stopped bool
+	elapsed time.Duration
+}
+
+// OpenTimer describes a timer that was started but not stopped, see
+// Emitter.WithTimerTracking.
+type OpenTimer struct {
+	Event    string
+	Started  time.Time
+	CallSite t.CallSiteDetails
+}
+
+// timerTracker holds the running timers of an emitter and its sub-emitters.
+type timerTracker struct {
+	mu   sync.Mutex
+	open map[*Timer]OpenTimer
+}
+
+// StartTimer starts a TIMER for event, to be emitted with props when the
+// returned timer is stopped. The call site of the event is the StartTimer
+// call, as recorded by the generator. ctx is kept for the emissions, so it
+// should not carry a deadline that ends before the timer is stopped.
+func (e *Emitter) StartTimer(ctx context.Context, event string, props map[string]interface{}) *Timer {
+	// Memoize the call site here rather than wherever the timer is stopped
+	var callSite t.CallSiteDetails
+	if e.timers != nil {
+		callSite = e.callSiteProps(e.eventName(event)).details()
+	} else {
+		callSite = e.eventCallSite(e.eventName(event))
+	}
+
+	now := time.Now()
+	timer := &Timer{
+		emitter: e,
+		ctx:     ctx,
+		event:   event,
+		props:   maps.Clone(props),
+		start:   now,
+		lap:     now,
+	}
+	if e.timers != nil {
+		e.timers.mu.Lock()
+		e.timers.open[timer] = OpenTimer{Event: e.eventName(event), Started: now, CallSite: callSite}
+		e.timers.mu.Unlock()
+	}
+	return timer
+}
+
+// Stop emits the time since the timer was started and returns it. Only the
+// first Stop or StopWith emits; later calls return the same duration.
+func (tm *Timer) Stop() time.Duration {
+	return tm.StopWith(nil)
+}
+
+// StopWith stops the timer like Stop, adding extraProps to the props it was
+// started with, e.g. to record the outcome.
+func (tm *Timer) StopWith(extraProps map[string]interface{}) time.Duration {
+	tm.mu.Lock()
+	if tm.stopped {
+		tm.mu.Unlock()
+		return tm.elapsed
+	}
+	tm.stopped = true
+	tm.elapsed = time.Since(tm.start)
+	tm.mu.Unlock()
+
+	if tracker := tm.emitter.timers; tracker != nil {
+		tracker.mu.Lock()
+		delete(tracker.open, tm)
+		tracker.mu.Unlock()
+	}
+
+	props := tm.props
+	if len(extraProps) > 0 {
+		props = make(map[string]interface{}, len(tm.props)+len(extraProps))
+		maps.Copy(props, tm.props)
+		maps.Copy(props, extraProps)
+	}
+	tm.emitter.EmitDuration(tm.ctx, tm.event, props, tm.elapsed, t.TIMER)
+	return tm.elapsed
+}
+
+// Lap emits the time since the previous lap, or since the timer was started,
+// as a TIMER named after the timer's event and name, and returns it. The timer
+// keeps running. Laps of a stopped timer are not emitted.
+func (tm *Timer) Lap(name string) time.Duration {
+	tm.mu.Lock()
+	if tm.stopped {
+		tm.mu.Unlock()
+		return 0
+	}
+	now := time.Now()
+	elapsed := now.Sub(tm.lap)
+	tm.lap = now
+	tm.mu.Unlock()
+
+	tm.emitter.EmitDuration(tm.ctx, tm.event+LapSeparator+name, tm.props, elapsed, t.TIMER)
+	return elapsed
+}
+
+// WithTimerTracking keeps track of the timers started by StartTimer on this
+// emitter and the sub-emitters created afterwards, so that tests can check
+// that every timer is stopped:
+//
+//	Expect(em.OpenTimers()).To(BeEmpty())
+func (e *Emitter) WithTimerTracking() *Emitter {
+	e.timers = &timerTracker{open: make(map[*Timer]OpenTimer)}
+	return e
+}
+
+// OpenTimers returns the timers that were started but not stopped, oldest
+// first. It always returns nil without WithTimerTracking.
+func (e *Emitter) OpenTimers() []OpenTimer {
+	if e.timers == nil {
+		return nil
+	}
+	e.timers.mu.Lock()
+	open := slices.Collect(maps.Values(e.timers.open))
+	e.timers.mu.Unlock()
+
+	slices.SortStableFunc(open, func(a, b OpenTimer) int {
+		return a.Started.Compare(b.Started)
+	})
+	return open
+}
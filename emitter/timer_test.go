@@ -0,0 +1,94 @@
+package emitter
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+var _ = Describe("StartTimer", func() {
+	var ctx context.Context
+	var recorder *eventRecorder
+	var emitter *Emitter
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &eventRecorder{}
+		emitter = NewEmitter().WithEventBackend(recorder)
+	})
+
+	It("Should emit the elapsed time once when stopped", func() {
+		props := map[string]interface{}{"route": "/users"}
+		timer := emitter.StartTimer(ctx, "request", props)
+		props["route"] = "/changed"
+		time.Sleep(time.Millisecond)
+
+		elapsed := timer.Stop()
+		Expect(elapsed).To(BeNumerically(">=", time.Millisecond))
+		Expect(timer.Stop()).To(Equal(elapsed))
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Name).To(Equal("request"))
+		Expect(events[0].MetricType).To(Equal(TIMER))
+		Expect(events[0].Duration).To(Equal(elapsed))
+		Expect(events[0].Props).To(HaveKeyWithValue("route", "/users"))
+	})
+
+	It("Should add extra props when stopped with them", func() {
+		timer := emitter.StartTimer(ctx, "request", map[string]interface{}{"route": "/users"})
+		timer.StopWith(map[string]interface{}{"status": 200})
+
+		Expect(recorder.Events()[0].Props).To(HaveKeyWithValue("route", "/users"))
+		Expect(recorder.Events()[0].Props).To(HaveKeyWithValue("status", 200))
+	})
+
+	It("Should emit laps as sub-timers", func() {
+		timer := emitter.StartTimer(ctx, "request", map[string]interface{}{"route": "/users"})
+		parse := timer.Lap("parse")
+		query := timer.Lap("query")
+		total := timer.Stop()
+		Expect(timer.Lap("late")).To(BeZero())
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(3))
+		Expect(events[0].Name).To(Equal("request.parse"))
+		Expect(events[0].Duration).To(Equal(parse))
+		Expect(events[0].Props).To(HaveKeyWithValue("route", "/users"))
+		Expect(events[1].Name).To(Equal("request.query"))
+		Expect(events[1].Duration).To(Equal(query))
+		Expect(events[2].Name).To(Equal("request"))
+		Expect(total).To(BeNumerically(">=", parse+query))
+	})
+
+	It("Should record the call site where the timer was started", func() {
+		emitter.WithMagicLineNo()
+		timer := emitter.StartTimer(ctx, "request", nil)
+		stop := func() { timer.Stop() }
+		stop()
+
+		Expect(recorder.Events()[0].CallSite.LineNo).To(Equal(CurrentSpecReport().LineNumber() + 2))
+	})
+
+	It("Should report timers that were never stopped", func() {
+		Expect(emitter.OpenTimers()).To(BeNil())
+
+		emitter.WithTimerTracking()
+		sub := emitter.NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "billing"})
+		stopped := emitter.StartTimer(ctx, "request", nil)
+		leaked := sub.StartTimer(ctx, "invoice", nil)
+		stopped.Stop()
+
+		open := emitter.OpenTimers()
+		Expect(open).To(HaveLen(1))
+		Expect(open[0].Event).To(Equal("billing.invoice"))
+		Expect(open[0].CallSite.Filename).To(HaveSuffix("timer_test.go"))
+
+		leaked.Stop()
+		Expect(emitter.OpenTimers()).To(BeEmpty())
+	})
+})
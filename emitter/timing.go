@@ -0,0 +1,109 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+const (
+	// OutcomeProp is the prop TimeErr sets to OutcomeSuccess, OutcomeError,
+	// OutcomePanic or OutcomeExit.
+	OutcomeProp = "outcome"
+	// ErrorClassProp is the prop TimeErr sets to the class of a returned error,
+	// when the timer has an error classifier.
+	ErrorClassProp = "error_class"
+
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+	OutcomePanic   = "panic"
+	// OutcomeExit is set when the timed function calls runtime.Goexit, as
+	// t.FailNow does.
+	OutcomeExit = "exit"
+)
+
+// TimerPanicEvent is the COUNT emitted when a function timed by TimeErr
+// panics. It carries the timer's props and an "event" prop with the timer's
+// full name; it is not prefixed on sub-emitters.
+const TimerPanicEvent = "emitter.timer.panic"
+
+// WithErrorClass returns a copy of the timer that tags the errors returned to
+// TimeErr with classify(err) in the ErrorClassProp prop. Classes should come
+// from a small fixed set, since each one is a separate series.
+func (e TimingEmitter[T]) WithErrorClass(classify func(error) string) TimingEmitter[T] {
+	e.errorClass = classify
+	return e
+}
+
+// ErrorTypeClass is an error classifier for WithErrorClass that uses the
+// error's dynamic type, e.g. "*net.OpError".
+func ErrorTypeClass(err error) string {
+	return fmt.Sprintf("%T", err)
+}
+
+// TimeErr times fn like Time, adding OutcomeProp to the props: OutcomeSuccess
+// when fn returns a nil error and OutcomeError otherwise. If fn panics, the
+// timer is recorded with OutcomePanic, TimerPanicEvent is counted, and the
+// panic is resumed. If fn calls runtime.Goexit, the timer is recorded with
+// OutcomeExit and the goroutine carries on exiting.
+func (e TimingEmitter[T]) TimeErr(ctx context.Context, event string, props map[string]interface{}, fn func() (T, error)) (T, error) {
+	start := time.Now()
+	finished := false
+	defer func() {
+		if finished {
+			return
+		}
+		r := recover()
+		if r == nil {
+			// runtime.Goexit; since Go 1.21 panic(nil) recovers a *runtime.PanicNilError
+			e.emitter.EmitDuration(ctx, event, timerProps(props, OutcomeExit), time.Since(start), t.TIMER)
+			return
+		}
+		e.emitter.EmitDuration(ctx, event, timerProps(props, OutcomePanic), time.Since(start), t.TIMER)
+		e.countPanic(ctx, event, props)
+		panic(r)
+	}()
+
+	r, err := fn()
+	elapsed := time.Since(start)
+	finished = true
+
+	p := timerProps(props, OutcomeSuccess)
+	if err != nil {
+		p[OutcomeProp] = OutcomeError
+		if e.errorClass != nil {
+			p[ErrorClassProp] = e.errorClass(err)
+		}
+	}
+	e.emitter.EmitDuration(ctx, event, p, elapsed, t.TIMER)
+	return r, err
+}
+
+// countPanic emits TimerPanicEvent for a panic in the timed function. On an
+// Emitter it is not prefixed, like the emitter's other events about itself,
+// and the "event" prop is the timer's full name.
+func (e TimingEmitter[T]) countPanic(ctx context.Context, event string, props map[string]interface{}) {
+	p := timerProps(props, OutcomePanic)
+	if em, ok := e.emitter.(*Emitter); ok {
+		p["event"] = em.eventName(event)
+		ev := eventFromProps(TimerPanicEvent, p, t.COUNT)
+		ev.Int = 1
+		em.emit(ctx, ev)
+		return
+	}
+	if counter, ok := e.emitter.(t.IntEmitter); ok {
+		p["event"] = event
+		counter.EmitInt(ctx, TimerPanicEvent, p, 1, t.COUNT)
+	}
+}
+
+// timerProps returns a copy of props with the outcome set.
+func timerProps(props map[string]interface{}, outcome string) map[string]interface{} {
+	p := make(map[string]interface{}, len(props)+2)
+	maps.Copy(p, props)
+	p[OutcomeProp] = outcome
+	return p
+}
@@ -0,0 +1,114 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+var _ = Describe("TimeErr", func() {
+	var ctx context.Context
+	var recorder *eventRecorder
+	var timer TimingEmitter[int]
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &eventRecorder{}
+		timer = NewTimingEmitter[int](NewEmitter().WithEventBackend(recorder))
+	})
+
+	It("Should tag successful calls", func() {
+		props := map[string]interface{}{"table": "users"}
+		result, err := timer.TimeErr(ctx, "db.save", props, func() (int, error) {
+			return 1, nil
+		})
+
+		Expect(result).To(Equal(1))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(props).NotTo(HaveKey(OutcomeProp))
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Name).To(Equal("db.save"))
+		Expect(events[0].MetricType).To(Equal(TIMER))
+		Expect(events[0].Props).To(HaveKeyWithValue("table", "users"))
+		Expect(events[0].Props).To(HaveKeyWithValue(OutcomeProp, OutcomeSuccess))
+		Expect(events[0].Props).NotTo(HaveKey(ErrorClassProp))
+	})
+
+	It("Should tag failed calls and classify the error when asked to", func() {
+		_, err := timer.TimeErr(ctx, "db.save", nil, func() (int, error) {
+			return 0, fs.ErrNotExist
+		})
+		Expect(err).To(MatchError(fs.ErrNotExist))
+		Expect(recorder.Events()[0].Props).To(HaveKeyWithValue(OutcomeProp, OutcomeError))
+		Expect(recorder.Events()[0].Props).NotTo(HaveKey(ErrorClassProp))
+
+		_, err = timer.WithErrorClass(ErrorTypeClass).TimeErr(ctx, "db.save", nil, func() (int, error) {
+			return 0, &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(recorder.Events()[1].Props).To(HaveKeyWithValue(ErrorClassProp, "*fs.PathError"))
+	})
+
+	It("Should record the timer and count the panic before re-panicking", func() {
+		boom := errors.New("boom")
+		Expect(func() {
+			timer.TimeErr(ctx, "db.save", map[string]interface{}{"table": "users"}, func() (int, error) {
+				panic(boom)
+			})
+		}).To(PanicWith(boom))
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].Name).To(Equal("db.save"))
+		Expect(events[0].Props).To(HaveKeyWithValue(OutcomeProp, OutcomePanic))
+		Expect(events[0].Props).NotTo(HaveKey("event"))
+		Expect(events[1].Name).To(Equal(TimerPanicEvent))
+		Expect(events[1].MetricType).To(Equal(COUNT))
+		Expect(events[1].Int).To(Equal(int64(1)))
+		Expect(events[1].Props).To(HaveKeyWithValue("event", "db.save"))
+		Expect(events[1].Props).To(HaveKeyWithValue("table", "users"))
+	})
+
+	It("Should count panics on sub-emitters under the unprefixed name", func() {
+		billing := NewEmitter().WithEventBackend(recorder).NewSubEmitterWithOptions(SubEmitterOptions{Prefix: "billing"})
+		Expect(func() {
+			NewTimingEmitter[int](billing).TimeErr(ctx, "db.save", nil, func() (int, error) {
+				panic("boom")
+			})
+		}).To(Panic())
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].Name).To(Equal("billing.db.save"))
+		Expect(events[1].Name).To(Equal(TimerPanicEvent))
+		Expect(events[1].Props).To(HaveKeyWithValue("event", "billing.db.save"))
+	})
+
+	It("Should record the timer and let runtime.Goexit finish", func() {
+		done := make(chan struct{})
+		returned := false
+		go func() {
+			defer close(done)
+			timer.TimeErr(ctx, "db.save", nil, func() (int, error) {
+				runtime.Goexit()
+				return 0, nil
+			})
+			returned = true
+		}()
+		<-done
+
+		Expect(returned).To(BeFalse())
+		events := recorder.Events()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Name).To(Equal("db.save"))
+		Expect(events[0].Props).To(HaveKeyWithValue(OutcomeProp, OutcomeExit))
+	})
+})
@@ -0,0 +1,48 @@
+package emitter
+
+import (
+	"context"
+	"maps"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Props added by trace correlation, see WithTraceCorrelation.
+const (
+	TraceIDProp    = "trace_id"
+	SpanIDProp     = "span_id"
+	TraceFlagsProp = "trace_flags"
+)
+
+// WithTraceCorrelation adds trace_id, span_id and trace_flags props to log
+// events whose context carries a valid OpenTelemetry span context, so that
+// logs can be joined with traces. Metric events are left alone because a
+// per-request ID would blow up their cardinality; see WithTraceCorrelationOnMetrics.
+func (e *Emitter) WithTraceCorrelation() *Emitter {
+	e.traceLogs = true
+	return e
+}
+
+// WithTraceCorrelationOnMetrics additionally adds the trace props to metric
+// events. Only enable this for backends that store exemplars or raw events.
+func (e *Emitter) WithTraceCorrelationOnMetrics() *Emitter {
+	e.traceLogs = true
+	e.traceMetrics = true
+	return e
+}
+
+// withTraceProps returns props with the trace props of ctx added. props is
+// returned unchanged when ctx has no valid span context, and is never modified.
+func withTraceProps(ctx context.Context, props map[string]interface{}) map[string]interface{} {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return props
+	}
+
+	p := make(map[string]interface{}, len(props)+3)
+	maps.Copy(p, props)
+	p[TraceIDProp] = sc.TraceID().String()
+	p[SpanIDProp] = sc.SpanID().String()
+	p[TraceFlagsProp] = sc.TraceFlags().String()
+	return p
+}
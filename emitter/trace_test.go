@@ -0,0 +1,87 @@
+package emitter
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+	"github.com/pseudofunctor-ai/go-emitter/emitter/types/mocks"
+)
+
+var _ = Describe("Trace correlation", func() {
+	var ctrl *gomock.Controller
+	var mockBackend *mocks.MockEmitterBackend
+	var spanCtx context.Context
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockBackend = mocks.NewMockEmitterBackend(ctrl)
+
+		sc := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+			SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+			TraceFlags: trace.FlagsSampled,
+		})
+		spanCtx = trace.ContextWithSpanContext(context.Background(), sc)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("Should add trace props to log events", func() {
+		emitter := NewEmitter(mockBackend).WithTraceCorrelation()
+
+		mockBackend.EXPECT().EmitInt(spanCtx, "log", gomock.Any(), int64(1), COUNT).Do(func(_ context.Context, _ string, props map[string]interface{}, _ int64, _ MetricType) {
+			Expect(props).To(HaveKeyWithValue(TraceIDProp, "0102030405060708090a0b0c0d0e0f10"))
+			Expect(props).To(HaveKeyWithValue(SpanIDProp, "0102030405060708"))
+			Expect(props).To(HaveKeyWithValue(TraceFlagsProp, "01"))
+		})
+		emitter.InfoContext(spanCtx, "log", nil, "hello")
+	})
+
+	It("Should not add trace props to metric events by default", func() {
+		emitter := NewEmitter(mockBackend).WithTraceCorrelation()
+
+		mockBackend.EXPECT().EmitInt(spanCtx, "metric", map[string]interface{}{}, int64(1), COUNT)
+		emitter.Count(spanCtx, "metric", nil, 1)
+	})
+
+	It("Should add trace props to metric events when enabled", func() {
+		emitter := NewEmitter(mockBackend).WithTraceCorrelationOnMetrics()
+
+		mockBackend.EXPECT().EmitInt(spanCtx, "metric", gomock.Any(), int64(1), COUNT).Do(func(_ context.Context, _ string, props map[string]interface{}, _ int64, _ MetricType) {
+			Expect(props).To(HaveKey(TraceIDProp))
+			Expect(props).To(HaveKey(SpanIDProp))
+		})
+		emitter.Count(spanCtx, "metric", nil, 1)
+	})
+
+	It("Should leave events without a span untouched", func() {
+		emitter := NewEmitter(mockBackend).WithTraceCorrelation()
+
+		mockBackend.EXPECT().EmitInt(gomock.Any(), "log", gomock.Any(), int64(1), COUNT).Do(func(_ context.Context, _ string, props map[string]interface{}, _ int64, _ MetricType) {
+			Expect(props).NotTo(HaveKey(TraceIDProp))
+		})
+		emitter.InfoContext(context.Background(), "log", nil, "hello")
+	})
+
+	It("Should not add trace props unless enabled", func() {
+		emitter := NewEmitter(mockBackend)
+
+		mockBackend.EXPECT().EmitInt(spanCtx, "log", gomock.Any(), int64(1), COUNT).Do(func(_ context.Context, _ string, props map[string]interface{}, _ int64, _ MetricType) {
+			Expect(props).NotTo(HaveKey(TraceIDProp))
+		})
+		emitter.InfoContext(spanCtx, "log", nil, "hello")
+	})
+
+	It("Should be inherited by sub-emitters", func() {
+		sub := NewEmitter(mockBackend).WithTraceCorrelation().NewSubEmitter().(*Emitter)
+		Expect(sub.traceLogs).To(BeTrue())
+		Expect(sub.traceMetrics).To(BeFalse())
+	})
+})
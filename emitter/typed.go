@@ -0,0 +1,213 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	t "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// dimensions describes the fields of a props struct used by the typed metric
+// handles. Each exported field is a dimension, named by its `emitter` tag or,
+// without one, by the field name. Fields tagged `emitter:"-"` are skipped.
+type dimensions struct {
+	keys   []string
+	fields []int
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func dimensionsOf[P any]() *dimensions {
+	typ := reflect.TypeOf((*P)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("metric props must be a struct, got %s", typ))
+	}
+
+	d := &dimensions{}
+	seen := make(map[string]string, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := field.Name
+		if tag, ok := field.Tag.Lookup("emitter"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				key = tag
+			}
+		}
+		if other, ok := seen[key]; ok {
+			panic(fmt.Sprintf("metric props %s: fields %s and %s both use key %q", typ, other, field.Name, key))
+		}
+		seen[key] = field.Name
+		d.keys = append(d.keys, key)
+		d.fields = append(d.fields, i)
+	}
+	return d
+}
+
+// seedProps returns placeholder props for seeding backends, as MetricWithProps does.
+func (d *dimensions) seedProps() map[string]interface{} {
+	props := make(map[string]interface{}, len(d.keys))
+	for _, key := range d.keys {
+		props[key] = "*"
+	}
+	return props
+}
+
+// attrs appends an attr for every dimension of props to dst.
+func (d *dimensions) attrs(dst []t.Attr, props reflect.Value) []t.Attr {
+	for i, idx := range d.fields {
+		key := d.keys[i]
+		v := props.Field(idx)
+		if v.Type() == durationType {
+			dst = append(dst, Duration(key, time.Duration(v.Int())))
+			continue
+		}
+		switch v.Kind() {
+		case reflect.String:
+			dst = append(dst, String(key, v.String()))
+		case reflect.Bool:
+			dst = append(dst, Bool(key, v.Bool()))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			dst = append(dst, Int64(key, v.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			dst = append(dst, Int64(key, int64(v.Uint())))
+		case reflect.Float32, reflect.Float64:
+			dst = append(dst, Float64(key, v.Float()))
+		default:
+			dst = append(dst, Any(key, v.Interface()))
+		}
+	}
+	return dst
+}
+
+// metricHandle is the part shared by all typed metric handles.
+type metricHandle[P any] struct {
+	emitter *Emitter
+	// event is the full name, including the emitter's prefix
+	event string
+	dims  *dimensions
+}
+
+func registerHandle[P any](em *Emitter, event string, metricType t.MetricType, opts []t.RegisterOption) metricHandle[P] {
+	dims := dimensionsOf[P]()
+	name := em.eventName(event)
+	em.register(name, metricType, dims.keys, opts)
+	em.seed(name, dims.seedProps(), metricType)
+	return metricHandle[P]{emitter: em, event: name, dims: dims}
+}
+
+// acquire returns a pooled event carrying props as attrs. It must be passed
+// to emitAttrs.
+func (h *metricHandle[P]) acquire(props P, metricType t.MetricType) *t.Event {
+	ev := acquireEvent(nil)
+	ev.Attrs = h.dims.attrs(ev.Attrs, reflect.ValueOf(&props).Elem())
+	ev.Name = h.event
+	ev.MetricType = metricType
+	return ev
+}
+
+// CounterHandle is a COUNT metric whose dimensions are the fields of P.
+type CounterHandle[P any] struct {
+	metricHandle[P]
+}
+
+// RegisterCounter registers a COUNT metric whose props are described by the
+// struct P, for example:
+//
+//	type RequestProps struct {
+//		Route  string `emitter:"route"`
+//		Status int    `emitter:"status"`
+//	}
+//
+//	var requests = emitter.RegisterCounter[RequestProps](em, "http_requests")
+//
+//	requests.Add(ctx, RequestProps{Route: "/users", Status: 200}, 1)
+//
+// The struct's dimensions are the metric's property keys in GetManifest, and
+// opts are the registration options accepted by Metric. It panics if P is not
+// a struct or the event is already registered.
+func RegisterCounter[P any](em *Emitter, event string, opts ...t.RegisterOption) *CounterHandle[P] {
+	return &CounterHandle[P]{registerHandle[P](em, event, t.COUNT, opts)}
+}
+
+// Add counts value occurrences of the event.
+func (c *CounterHandle[P]) Add(ctx context.Context, props P, value int64) {
+	ev := c.acquire(props, t.COUNT)
+	ev.ValueKind = t.IntValue
+	ev.Int = value
+	c.emitter.emitAttrs(ctx, ev)
+}
+
+// Inc counts one occurrence of the event.
+func (c *CounterHandle[P]) Inc(ctx context.Context, props P) {
+	c.Add(ctx, props, 1)
+}
+
+// GaugeHandle is a GAUGE metric whose dimensions are the fields of P.
+type GaugeHandle[P any] struct {
+	metricHandle[P]
+}
+
+// RegisterGauge registers a GAUGE metric whose props are described by the
+// struct P. See RegisterCounter.
+func RegisterGauge[P any](em *Emitter, event string, opts ...t.RegisterOption) *GaugeHandle[P] {
+	return &GaugeHandle[P]{registerHandle[P](em, event, t.GAUGE, opts)}
+}
+
+// Set records the current value of the gauge.
+func (g *GaugeHandle[P]) Set(ctx context.Context, props P, value float64) {
+	ev := g.acquire(props, t.GAUGE)
+	ev.ValueKind = t.FloatValue
+	ev.Float = value
+	g.emitter.emitAttrs(ctx, ev)
+}
+
+// HistogramHandle is a HISTOGRAM metric whose dimensions are the fields of P.
+type HistogramHandle[P any] struct {
+	metricHandle[P]
+}
+
+// RegisterHistogram registers a HISTOGRAM metric whose props are described by
+// the struct P. See RegisterCounter.
+func RegisterHistogram[P any](em *Emitter, event string, opts ...t.RegisterOption) *HistogramHandle[P] {
+	return &HistogramHandle[P]{registerHandle[P](em, event, t.HISTOGRAM, opts)}
+}
+
+// Observe records a single observation.
+func (h *HistogramHandle[P]) Observe(ctx context.Context, props P, value float64) {
+	ev := h.acquire(props, t.HISTOGRAM)
+	ev.ValueKind = t.FloatValue
+	ev.Float = value
+	h.emitter.emitAttrs(ctx, ev)
+}
+
+// TimerHandle is a TIMER metric whose dimensions are the fields of P.
+type TimerHandle[P any] struct {
+	metricHandle[P]
+}
+
+// RegisterTimer registers a TIMER metric whose props are described by the
+// struct P. See RegisterCounter.
+func RegisterTimer[P any](em *Emitter, event string, opts ...t.RegisterOption) *TimerHandle[P] {
+	return &TimerHandle[P]{registerHandle[P](em, event, t.TIMER, opts)}
+}
+
+// Record records a single duration.
+func (h *TimerHandle[P]) Record(ctx context.Context, props P, value time.Duration) {
+	ev := h.acquire(props, t.TIMER)
+	ev.ValueKind = t.DurationValue
+	ev.Duration = value
+	h.emitter.emitAttrs(ctx, ev)
+}
+
+// Since records the time elapsed since start.
+func (h *TimerHandle[P]) Since(ctx context.Context, props P, start time.Time) {
+	h.Record(ctx, props, time.Since(start))
+}
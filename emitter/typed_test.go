@@ -0,0 +1,119 @@
+package emitter
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+type requestProps struct {
+	Route   string        `emitter:"route"`
+	Status  int           `emitter:"status"`
+	Cached  bool          `emitter:"cached"`
+	Timeout time.Duration `emitter:"timeout"`
+	Region  string
+	Ignored string `emitter:"-"`
+	private string
+}
+
+var _ = Describe("Typed metric handles", func() {
+	var ctx context.Context
+	var recorder *eventRecorder
+	var emitter *Emitter
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &eventRecorder{}
+		emitter = NewEmitter().WithEventBackend(recorder)
+	})
+
+	It("Should derive property keys from the struct", func() {
+		RegisterCounter[requestProps](emitter, "requests")
+
+		manifest := emitter.GetManifest()
+		Expect(manifest).To(HaveLen(1))
+		Expect(manifest[0].MetricType).To(Equal(COUNT))
+		Expect(manifest[0].PropertyKeys).To(Equal([]string{"route", "status", "cached", "timeout", "Region"}))
+	})
+
+	It("Should seed backends with placeholder props", func() {
+		RegisterGauge[requestProps](emitter, "inflight")
+
+		events := recorder.Events()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Props).To(Equal(map[string]interface{}{"route": "*", "status": "*", "cached": "*", "timeout": "*", "Region": "*"}))
+	})
+
+	It("Should emit the struct's fields as typed attrs", func() {
+		requests := RegisterCounter[requestProps](emitter, "requests")
+
+		requests.Add(ctx, requestProps{Route: "/users", Status: 200, Cached: true, Timeout: time.Second, Region: "eu", Ignored: "x", private: "y"}, 3)
+
+		ev := recorder.Events()[1]
+		Expect(ev.Name).To(Equal("requests"))
+		Expect(ev.MetricType).To(Equal(COUNT))
+		Expect(ev.Int).To(Equal(int64(3)))
+		Expect(ev.Attrs).To(Equal([]Attr{
+			String("route", "/users"),
+			Int("status", 200),
+			Bool("cached", true),
+			Duration("timeout", time.Second),
+			String("Region", "eu"),
+		}))
+	})
+
+	It("Should support gauges, histograms and timers", func() {
+		type props struct {
+			Queue string `emitter:"queue"`
+		}
+		depth := RegisterGauge[props](emitter, "queue.depth")
+		size := RegisterHistogram[props](emitter, "queue.size")
+		wait := RegisterTimer[props](emitter, "queue.wait")
+
+		depth.Set(ctx, props{Queue: "a"}, 3)
+		size.Observe(ctx, props{Queue: "a"}, 1.5)
+		wait.Record(ctx, props{Queue: "a"}, time.Second)
+
+		events := recorder.Events()[3:]
+		Expect(events).To(HaveLen(3))
+		Expect(events[0].MetricType).To(Equal(GAUGE))
+		Expect(events[0].Float).To(Equal(3.0))
+		Expect(events[1].MetricType).To(Equal(HISTOGRAM))
+		Expect(events[1].Float).To(Equal(1.5))
+		Expect(events[2].MetricType).To(Equal(TIMER))
+		Expect(events[2].Duration).To(Equal(time.Second))
+		for _, ev := range events {
+			Expect(ev.Attrs).To(Equal([]Attr{String("queue", "a")}))
+		}
+	})
+
+	It("Should pass the dimensions to EmitterBackends as props", func() {
+		backend := newGatedBackend()
+		backend.open()
+		emitter := NewEmitter(backend)
+		type props struct {
+			Route string `emitter:"route"`
+		}
+
+		RegisterCounter[props](emitter, "requests").Inc(ctx, props{Route: "/"})
+
+		Expect(backend.Value("requests")).To(Equal(int64(1)))
+		Expect(backend.Props("requests")).To(Equal(map[string]interface{}{"route": "/"}))
+	})
+
+	It("Should reject props that aren't structs, duplicate keys and duplicate registrations", func() {
+		type duplicate struct {
+			A string `emitter:"key"`
+			B string `emitter:"key"`
+		}
+		Expect(func() { RegisterCounter[string](emitter, "string") }).To(Panic())
+		Expect(func() { RegisterCounter[duplicate](emitter, "duplicate") }).To(Panic())
+
+		RegisterTimer[requestProps](emitter, "event")
+		Expect(func() { RegisterTimer[requestProps](emitter, "event") }).To(Panic())
+	})
+})
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AttrKind says which of an Attr's value fields is set.
+type AttrKind int
+
+const (
+	AttrString AttrKind = iota
+	AttrInt64
+	AttrFloat64
+	AttrBool
+	AttrDuration
+	AttrAny
+)
+
+// Attr is a typed property. Unlike a props map entry its value is not boxed,
+// so events built from attrs can be emitted without allocating.
+type Attr struct {
+	Key  string
+	Kind AttrKind
+	// Str holds AttrString values
+	Str string
+	// Num holds AttrInt64 values, AttrBool values as 0 or 1, and AttrDuration
+	// values in nanoseconds
+	Num   int64
+	Float float64
+	Any   interface{}
+}
+
+// Value returns the attr's value as the Go type it was created from. It boxes
+// the value, so backends on the hot path should switch on Kind instead.
+func (a Attr) Value() interface{} {
+	switch a.Kind {
+	case AttrString:
+		return a.Str
+	case AttrInt64:
+		return a.Num
+	case AttrFloat64:
+		return a.Float
+	case AttrBool:
+		return a.Num != 0
+	case AttrDuration:
+		return time.Duration(a.Num)
+	default:
+		return a.Any
+	}
+}
+
+// String formats the attr's value as fmt's %v verb would.
+func (a Attr) String() string {
+	switch a.Kind {
+	case AttrString:
+		return a.Str
+	case AttrInt64:
+		return strconv.FormatInt(a.Num, 10)
+	case AttrFloat64:
+		return strconv.FormatFloat(a.Float, 'g', -1, 64)
+	case AttrBool:
+		return strconv.FormatBool(a.Num != 0)
+	case AttrDuration:
+		return time.Duration(a.Num).String()
+	default:
+		return fmt.Sprintf("%v", a.Any)
+	}
+}
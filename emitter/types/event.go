@@ -0,0 +1,137 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Level is the severity of a log event.
+type Level int32
+
+const (
+	LevelTrace Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelTrace:
+		return "TRACE"
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	case LevelFatal:
+		return "FATAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseLevel parses a level name such as "DEBUG", case-insensitively.
+func ParseLevel(s string) (Level, error) {
+	for l := LevelTrace; l <= LevelFatal; l++ {
+		if strings.EqualFold(s, l.String()) {
+			return l, nil
+		}
+	}
+	return LevelTrace, fmt.Errorf("unknown log level %q", s)
+}
+
+// EventKind tells metric events and log events apart.
+type EventKind int
+
+const (
+	MetricEvent EventKind = iota
+	LogEvent
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case MetricEvent:
+		return "METRIC"
+	case LogEvent:
+		return "LOG"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ValueKind says which of an Event's value fields is set.
+type ValueKind int
+
+const (
+	IntValue ValueKind = iota
+	FloatValue
+	DurationValue
+)
+
+// Event is a single emission as seen by an EventBackend. Log events are
+// COUNTs with an Int value of 1, or the number of occurrences a suppression
+// summary stands for.
+type Event struct {
+	Kind       EventKind
+	Name       string
+	MetricType MetricType
+
+	ValueKind ValueKind
+	Int       int64
+	Float     float64
+	Duration  time.Duration
+
+	// Level and Message are only set for log events
+	Level   Level
+	Message string
+
+	// Props are the event's properties, including context props, magic props
+	// and anything added by the emitter's callback.
+	Props map[string]interface{}
+	// Attrs are typed properties passed to the *Attrs emitter methods. When an
+	// attr and a prop share a key, the attr wins.
+	Attrs []Attr
+	// CallSite is where the event was emitted. It is only looked up when the
+	// emitter has magic props, a callback or a callsite provider, and is
+	// empty otherwise.
+	CallSite  CallSiteDetails
+	Timestamp time.Time
+
+	// SampleRate is the rate the emitter kept the event at. 0 and 1 both mean
+	// the event was not sampled; see Sampled.
+	SampleRate float64
+}
+
+// Sampled reports whether the emitter sampled the event, in which case
+// counting backends should scale counts by 1/SampleRate.
+func (e *Event) Sampled() bool {
+	return e.SampleRate > 0 && e.SampleRate < 1
+}
+
+// Float64 returns the event's value as a float64, with durations in seconds.
+func (e *Event) Float64() float64 {
+	switch e.ValueKind {
+	case FloatValue:
+		return e.Float
+	case DurationValue:
+		return e.Duration.Seconds()
+	default:
+		return float64(e.Int)
+	}
+}
+
+// EventBackend receives whole events, including the log level and message as
+// fields rather than reserved props. Backends that implement it are called
+// instead of their EmitterBackend methods. The event, including its Attrs, is
+// only valid for the duration of the call and must not be modified.
+type EventBackend interface {
+	Emit(ctx context.Context, event *Event)
+}
@@ -13,8 +13,42 @@ type CallSiteDetails struct {
 	Package      string
 	PropertyKeys []string
 	MetricType   string
+	// Description, Unit, Owner, RunbookURL and Tags are filled in by the
+	// generator from literal registration options
+	Description string
+	Unit        string
+	Owner       string
+	RunbookURL  string
+	Tags        []string
+}
+
+// Info returns the registration options recorded in the details.
+func (d CallSiteDetails) Info() EventInfo {
+	return EventInfo{
+		Description: d.Description,
+		Unit:        d.Unit,
+		Owner:       d.Owner,
+		RunbookURL:  d.RunbookURL,
+		Tags:        d.Tags,
+	}
+}
+
+// EventInfo documents a registered event, for the manifest and for backends
+// that implement Describer.
+type EventInfo struct {
+	Description string   `json:"description,omitempty"`
+	Unit        string   `json:"unit,omitempty"`
+	Owner       string   `json:"owner,omitempty"`
+	RunbookURL  string   `json:"runbook_url,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// Buckets are the bucket boundaries suggested for a histogram or timer,
+	// in Unit
+	Buckets []float64 `json:"buckets,omitempty"`
 }
 
+// RegisterOption sets part of the EventInfo of an event being registered.
+type RegisterOption func(*EventInfo)
+
 type SimpleLogger interface {
 	Info(event string, props map[string]interface{}, msg string)
 	Warn(event string, props map[string]interface{}, msg string)
@@ -86,10 +120,10 @@ type CombinedEmitter interface {
   DurationEmitter
   FloatEmitter
   IntEmitter
-	Metric(event string, metricType MetricType) MetricEmitterFn
-	MetricWithProps(event string, metricType MetricType, propKeys []string) MetricEmitterFn
-	Log(event string, logfn func(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{})) LogEmitterFn
-	LogWithProps(event string, logfn func(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}), propKeys []string) LogEmitterFn
+	Metric(event string, metricType MetricType, opts ...RegisterOption) MetricEmitterFn
+	MetricWithProps(event string, metricType MetricType, propKeys []string, opts ...RegisterOption) MetricEmitterFn
+	Log(event string, logfn func(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}), opts ...RegisterOption) LogEmitterFn
+	LogWithProps(event string, logfn func(ctx context.Context, event string, props map[string]interface{}, format string, args ...interface{}), propKeys []string, opts ...RegisterOption) LogEmitterFn
   NewSubEmitter() CombinedEmitter
   WithStaticMetadata(staticData map[string]CallSiteDetails) CombinedEmitter
   MetricFnCallsite(fn MetricEmitterFn) MetricEmitterFn
@@ -143,16 +177,70 @@ type MetricsTimer[T any] interface {
 	Time(ctx context.Context, event string, props map[string]interface{}, fn func() T) T
 }
 
+// MetricsTimerErr is a MetricsTimer that can also time calls returning an
+// error, tagging the timer with their outcome.
+type MetricsTimerErr[T any] interface {
+	MetricsTimer[T]
+	TimeErr(ctx context.Context, event string, props map[string]interface{}, fn func() (T, error)) (T, error)
+}
+
 type EmitterBackend interface {
 	EmitDuration(ctx context.Context, event string, props map[string]interface{}, value time.Duration, metricType MetricType)
 	EmitFloat(ctx context.Context, event string, props map[string]interface{}, value float64, metricType MetricType)
 	EmitInt(ctx context.Context, event string, props map[string]interface{}, value int64, metricType MetricType)
 }
 
+// Starter is an optional interface for backends that need to open connections
+// or start goroutines before the first event. Emitter.Start calls it.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Flusher is an optional interface for backends that buffer events, such as
+// batched UDP packets. Emitter.Flush and Emitter.Shutdown call it.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// Closer is an optional interface for backends that hold resources which must be
+// released on shutdown. Emitter.Shutdown calls it after every backend has been flushed.
+type Closer interface {
+	Close(ctx context.Context) error
+}
+
+// ErrorHandler receives errors that a backend hit while emitting event.
+type ErrorHandler func(ctx context.Context, event string, err error)
+
+// ErrorReporter is an optional interface for backends that can fail while
+// emitting. The emitter installs a handler when the backend is added, which
+// forwards errors to the emitter's counters and to Emitter.WithErrorHandler.
+type ErrorReporter interface {
+	SetErrorHandler(handler ErrorHandler)
+}
+
+// Describer is implemented by backends that use the EventInfo of registered
+// events, e.g. for instrument descriptions and units. The emitter calls
+// Describe when an event is registered, before seeding it, on the backends
+// it has at that point.
+type Describer interface {
+	Describe(event string, metricType MetricType, info EventInfo)
+}
+
 // MetricManifestEntry represents a single metric in the manifest
 type MetricManifestEntry struct {
 	Name         string     `json:"name"`
 	MetricType   MetricType `json:"metric_type"`
 	TypeString   string     `json:"type_string"`
 	PropertyKeys []string   `json:"property_keys,omitempty"`
+	EventInfo
+	// Cardinality is the number of distinct prop value combinations seen so
+	// far, tracked only for events with a cardinality limit
+	Cardinality      int `json:"cardinality,omitempty"`
+	CardinalityLimit int `json:"cardinality_limit,omitempty"`
+	// Filename, LineNo, FuncName and Package locate the event's call site,
+	// when it is known from static metadata or an earlier emission
+	Filename string `json:"filename,omitempty"`
+	LineNo   int    `json:"line_no,omitempty"`
+	FuncName string `json:"func_name,omitempty"`
+	Package  string `json:"package,omitempty"`
 }
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
@@ -28,6 +29,13 @@ type CallSite struct {
 	Package      string
 	PropertyKeys []string
 	MetricType   string
+	// Description, Unit, Owner, RunbookURL and Tags come from literal
+	// registration options such as emitter.WithDescription("...")
+	Description string
+	Unit        string
+	Owner       string
+	RunbookURL  string
+	Tags        []string
 }
 
 // Generate is the main entry point for the generator
@@ -98,6 +106,7 @@ func extractCallSites(pkg *packages.Package) (map[string]CallSite, error) {
 		extractor.currentFile = filename
 		ast.Walk(extractor, file)
 	}
+	extractor.applyRegisterOptions()
 
 	return extractor.callsites, extractor.err
 }
@@ -106,7 +115,10 @@ type callSiteExtractor struct {
 	pkg         *packages.Package
 	currentFile string
 	callsites   map[string]CallSite
-	err         error
+	// options holds the registration options found on Metric/Log calls, by
+	// event name, until they are copied onto the invocation callsites
+	options map[string]CallSite
+	err     error
 }
 
 // Visit implements ast.Visitor
@@ -145,6 +157,8 @@ func (e *callSiteExtractor) Visit(node ast.Node) ast.Visitor {
 		if !isRegistrationMethod(methodName) {
 			// This is a direct emitter call like em.Count() - record it
 			e.recordCallsite(eventName, callsite, false)
+		} else {
+			e.recordRegisterOptions(eventName, callExpr, methodName)
 		}
 	}
 
@@ -887,8 +901,8 @@ func (e *callSiteExtractor) extractFromCall(call *ast.CallExpr) (string, CallSit
 
 	methodName := selExpr.Sel.Name
 
-	// Handle timer calls first (timer.Time(...))
-	if methodName == "Time" {
+	// Handle timer calls first (timer.Time(...) and timer.TimeErr(...))
+	if methodName == "Time" || methodName == "TimeErr" {
 		eventName, callsite := e.extractTimerCall(call)
 		if eventName != "" {
 			return eventName, callsite, false
@@ -939,10 +953,10 @@ func (e *callSiteExtractor) extractDirectCall(call *ast.CallExpr, methodName str
 	return eventName, callsite
 }
 
-// extractTimerCall extracts event name from timer.Time() calls
+// extractTimerCall extracts event name from timer.Time() and timer.TimeErr() calls
 func (e *callSiteExtractor) extractTimerCall(call *ast.CallExpr) (string, CallSite) {
 	// Timer.Time signature: Time(ctx context.Context, event string, props map[string]interface{}, fn func() T) T
-	// We need exactly 4 arguments
+	// TimeErr only differs in fn returning (T, error), so both need exactly 4 arguments
 	if len(call.Args) != 4 {
 		return "", CallSite{}
 	}
@@ -970,7 +984,7 @@ func (e *callSiteExtractor) extractTimerCall(call *ast.CallExpr) (string, CallSi
 		}
 	}
 
-	// arg[3]: func() T (function)
+	// arg[3]: func() T or func() (T, error) (function)
 	if tv, ok := e.pkg.TypesInfo.Types[call.Args[3]]; ok {
 		if !e.matchesType(tv.Type, paramFunc) {
 			return "", CallSite{}
@@ -1238,6 +1252,100 @@ func (e *callSiteExtractor) extractCallSiteFromValueSpec(spec *ast.ValueSpec) Ca
 	return CallSite{}
 }
 
+// registerOptionArgs is the number of arguments registration methods take
+// before their variadic options
+var registerOptionArgs = map[string]int{
+	"Metric":          2,
+	"MetricWithProps": 3,
+	"Log":             2,
+	"LogWithProps":    3,
+}
+
+// recordRegisterOptions remembers the literal options of a registration call,
+// e.g. em.Metric("latency", types.TIMER, emitter.WithUnit("ms")). Options
+// that are not calls to the emitter package's option functions with literal
+// arguments are ignored.
+func (e *callSiteExtractor) recordRegisterOptions(eventName string, call *ast.CallExpr, methodName string) {
+	first, ok := registerOptionArgs[methodName]
+	if !ok || len(call.Args) <= first {
+		return
+	}
+
+	var opts CallSite
+	for _, arg := range call.Args[first:] {
+		optCall, ok := arg.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		var ident *ast.Ident
+		switch fun := optCall.Fun.(type) {
+		case *ast.Ident:
+			ident = fun
+		case *ast.SelectorExpr:
+			ident = fun.Sel
+		default:
+			continue
+		}
+		obj := e.pkg.TypesInfo.Uses[ident]
+		if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != "github.com/pseudofunctor-ai/go-emitter/emitter" {
+			continue
+		}
+
+		values := make([]string, 0, len(optCall.Args))
+		for _, a := range optCall.Args {
+			lit, ok := a.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				values = nil
+				break
+			}
+			value, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				values = nil
+				break
+			}
+			values = append(values, value)
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		switch ident.Name {
+		case "WithDescription":
+			opts.Description = values[0]
+		case "WithUnit":
+			opts.Unit = values[0]
+		case "WithOwner":
+			opts.Owner = values[0]
+		case "WithRunbookURL":
+			opts.RunbookURL = values[0]
+		case "WithTags":
+			opts.Tags = append(opts.Tags, values...)
+		}
+	}
+
+	if e.options == nil {
+		e.options = make(map[string]CallSite)
+	}
+	e.options[eventName] = opts
+}
+
+// applyRegisterOptions copies the recorded registration options onto the
+// callsites of the registered events
+func (e *callSiteExtractor) applyRegisterOptions() {
+	for eventName, opts := range e.options {
+		callsite, ok := e.callsites[eventName]
+		if !ok {
+			continue
+		}
+		callsite.Description = opts.Description
+		callsite.Unit = opts.Unit
+		callsite.Owner = opts.Owner
+		callsite.RunbookURL = opts.RunbookURL
+		callsite.Tags = opts.Tags
+		e.callsites[eventName] = callsite
+	}
+}
+
 // extractEventNameArg extracts the event name string literal from a call expression
 func (e *callSiteExtractor) extractEventNameArg(call *ast.CallExpr, methodName string) string {
 	argIndex := getEventNameArgIndex(methodName)
@@ -1375,6 +1483,8 @@ func inferMetricTypeFromMethod(methodName string) string {
 		return "GAUGE"
 	case "Histogram":
 		return "HISTOGRAM"
+	case "StartTimer":
+		return "TIMER"
 	case "Meter":
 		return "METER"
 	case "Set":
@@ -1507,6 +1617,7 @@ var emitterMethodSignatures = map[string]methodSignature{
 	"Set":          {[]paramType{paramContext, paramString, paramProps, paramInt64}},
 	"Event":        {[]paramType{paramContext, paramString, paramProps}},
 	"Histogram":    {[]paramType{paramContext, paramString, paramProps}},
+	"StartTimer":   {[]paramType{paramContext, paramString, paramProps}},
 	"EmitInt":      {[]paramType{paramContext, paramString, paramProps, paramInt64, paramMetricType}},
 	"EmitFloat":    {[]paramType{paramContext, paramString, paramProps, paramFloat64, paramMetricType}},
 	"EmitDuration": {[]paramType{paramContext, paramString, paramProps, paramVariadic, paramMetricType}},
@@ -1543,11 +1654,11 @@ var emitterMethodSignatures = map[string]methodSignature{
 	"DebugfContext": {[]paramType{paramContext, paramString, paramProps, paramString, paramVariadic}},
 	"TracefContext": {[]paramType{paramContext, paramString, paramProps, paramString, paramVariadic}},
 
-	// Registration methods
-	"Metric":          {[]paramType{paramString, paramMetricType}},
-	"MetricWithProps": {[]paramType{paramString, paramMetricType, paramStringSlice}},
-	"Log":             {[]paramType{paramString, paramFunc}},
-	"LogWithProps":    {[]paramType{paramString, paramFunc, paramStringSlice}},
+	// Registration methods: (event, ..., ...opts)
+	"Metric":          {[]paramType{paramString, paramMetricType, paramVariadic}},
+	"MetricWithProps": {[]paramType{paramString, paramMetricType, paramStringSlice, paramVariadic}},
+	"Log":             {[]paramType{paramString, paramFunc, paramVariadic}},
+	"LogWithProps":    {[]paramType{paramString, paramFunc, paramStringSlice, paramVariadic}},
 }
 
 // matchesType checks if a given type matches the expected parameter type
@@ -1688,7 +1799,7 @@ func getEventNameArgIndex(methodName string) int {
 	// For most methods, event name is the first parameter after context (if any)
 	// Context methods have ctx as first param, so event is at index 1
 	contextMethods := map[string]bool{
-		"Count": true, "Gauge": true, "Histogram": true, "Meter": true, "Set": true, "Event": true,
+		"Count": true, "Gauge": true, "Histogram": true, "Meter": true, "Set": true, "Event": true, "StartTimer": true,
 		"InfoContext": true, "WarnContext": true, "ErrorContext": true, "FatalContext": true,
 		"DebugContext": true, "TraceContext": true,
 		"InfofContext": true, "WarnfContext": true, "ErrorfContext": true, "FatalfContext": true,
@@ -1709,7 +1820,7 @@ func getPropsArgIndex(methodName string) int {
 	// Context methods: Count(ctx, event, props, value)
 	// Props is at index 2 for context methods
 	contextMethods := map[string]bool{
-		"Count": true, "Gauge": true, "Histogram": true, "Meter": true, "Set": true, "Event": true,
+		"Count": true, "Gauge": true, "Histogram": true, "Meter": true, "Set": true, "Event": true, "StartTimer": true,
 		"InfoContext": true, "WarnContext": true, "ErrorContext": true, "FatalContext": true,
 		"DebugContext": true, "TraceContext": true,
 		"InfofContext": true, "WarnfContext": true, "ErrorfContext": true, "FatalfContext": true,
@@ -1772,6 +1883,21 @@ func writeOutputFile(outputPath, pkgName, varName string, callsites map[string]C
 		} else {
 			w.writeLine("\t\tMetricType:   \"\",")
 		}
+		if cs.Description != "" {
+			w.writeLine("\t\tDescription:  %q,", cs.Description)
+		}
+		if cs.Unit != "" {
+			w.writeLine("\t\tUnit:         %q,", cs.Unit)
+		}
+		if cs.Owner != "" {
+			w.writeLine("\t\tOwner:        %q,", cs.Owner)
+		}
+		if cs.RunbookURL != "" {
+			w.writeLine("\t\tRunbookURL:   %q,", cs.RunbookURL)
+		}
+		if len(cs.Tags) > 0 {
+			w.writeLine("\t\tTags:         []string{%s},", formatStringSlice(cs.Tags))
+		}
 		w.writeLine("\t},")
 	}
 
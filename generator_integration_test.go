@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -361,6 +362,21 @@ func TestGeneratorIntegration(t *testing.T) {
 					PropertyKeys: []string{"source"},
 					MetricType:   "TIMER",
 				},
+				"service.db.save": {
+					EventName:    "service.db.save",
+					LineNo:       190,
+					FuncName:     "github.com/pseudofunctor-ai/go-emitter/testdata/example.SaveRecord",
+					PropertyKeys: []string{"table"},
+					MetricType:   "TIMER",
+				},
+				// Stopwatch timers are recorded where they are started
+				"stopwatch.timer.event": {
+					EventName:    "stopwatch.timer.event",
+					LineNo:       200,
+					FuncName:     "github.com/pseudofunctor-ai/go-emitter/testdata/example.StopwatchTimer",
+					PropertyKeys: []string{"stage"},
+					MetricType:   "TIMER",
+				},
 				// Advanced patterns: Direct function result indexing
 				"inline_slice_index_0": {
 					EventName:    "inline_slice_index_0",
@@ -477,6 +493,18 @@ func TestGeneratorIntegration(t *testing.T) {
 					PropertyKeys: nil, // Props at invocation site, not definition
 					MetricType:   "COUNT",
 				},
+				// Registration options are copied from the registration to the invocation
+				"checkout_latency": {
+					EventName:   "checkout_latency",
+					LineNo:      21,
+					FuncName:    "github.com/pseudofunctor-ai/go-emitter/testdata/example.RegistrationOptions",
+					MetricType:  "TIMER",
+					Description: "Time to complete a checkout",
+					Unit:        "ms",
+					Owner:       "payments",
+					RunbookURL:  "https://runbooks.example.com/checkout",
+					Tags:        []string{"slo", "checkout"},
+				},
 				// Decorator override (decorator should win, marking line 144 not 138)
 				"decorator_override_event": {
 					EventName:    "decorator_override_event",
@@ -565,6 +593,12 @@ func TestGeneratorIntegration(t *testing.T) {
 					t.Errorf("event %q: expected MetricType %q, got %q", eventName, expectedSite.MetricType, actualSite.MetricType)
 				}
 
+				if actualSite.Description != expectedSite.Description || actualSite.Unit != expectedSite.Unit ||
+					actualSite.Owner != expectedSite.Owner || actualSite.RunbookURL != expectedSite.RunbookURL ||
+					strings.Join(actualSite.Tags, ",") != strings.Join(expectedSite.Tags, ",") {
+					t.Errorf("event %q: expected registration options %+v, got %+v", eventName, expectedSite, actualSite)
+				}
+
 				// Compare property keys
 				if len(actualSite.PropertyKeys) != len(expectedSite.PropertyKeys) {
 					t.Errorf("event %q: expected PropertyKeys %v, got %v", eventName, expectedSite.PropertyKeys, actualSite.PropertyKeys)
@@ -0,0 +1,22 @@
+package example
+
+import (
+	"context"
+
+	"github.com/pseudofunctor-ai/go-emitter/emitter"
+	"github.com/pseudofunctor-ai/go-emitter/emitter/types"
+)
+
+// Test registration options
+var checkoutLatency = em.Metric("checkout_latency", types.TIMER,
+	emitter.WithDescription("Time to complete a checkout"),
+	emitter.WithUnit("ms"),
+	emitter.WithOwner("payments"),
+	emitter.WithRunbookURL("https://runbooks.example.com/checkout"),
+	emitter.WithTags("slo", "checkout"),
+)
+
+func RegistrationOptions() {
+	ctx := context.Background()
+	checkoutLatency(ctx, nil, 12.5)
+}
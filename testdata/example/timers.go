@@ -16,7 +16,7 @@ type ServiceWithTimers struct {
 }
 
 type ServiceTimers struct {
-	dbQueryTimer    types.MetricsTimer[int]
+	dbQueryTimer    types.MetricsTimerErr[int]
 	apiCallTimer    types.MetricsTimer[string]
 	processingTimer types.MetricsTimer[bool]
 }
@@ -184,3 +184,25 @@ func TimerFromFunction(ctx context.Context) bool {
 		return false
 	})
 }
+
+// Pattern 9: Timing a call that can fail
+func (s *ServiceWithTimers) SaveRecord(ctx context.Context) (int, error) {
+	return s.timers.dbQueryTimer.TimeErr(ctx, "service.db.save", map[string]interface{}{
+		"table": "users",
+	}, func() (int, error) {
+		time.Sleep(1 * time.Millisecond)
+		return 1, nil
+	})
+}
+
+// Pattern 10: Stopwatch timer stopped in another function
+func StopwatchTimer(ctx context.Context, em *emitter.Emitter) {
+	timer := em.StartTimer(ctx, "stopwatch.timer.event", map[string]interface{}{
+		"stage": "upload",
+	})
+	finishUpload(timer)
+}
+
+func finishUpload(timer *emitter.Timer) {
+	timer.StopWith(map[string]interface{}{"status": "ok"})
+}